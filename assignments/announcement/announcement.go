@@ -0,0 +1,212 @@
+// Package announcement fetches the announcements of the enrolled courses
+// and keeps track of the ones already read.
+package announcement
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// Announcement is an announcement of a course. Body and Attachments are
+// only filled for the announcements whose page was fetched; see Get.
+type Announcement struct {
+	ID          string
+	Course      *course.Course
+	Title       string
+	Date        time.Time
+	Body        string
+	Attachments []assignment.Attachment
+	URL         string
+}
+
+// Key identifies the announcement across courses.
+func (a *Announcement) Key() string {
+	return a.Course.ID + "/" + a.ID
+}
+
+// Get fetches the announcements of the courses, newest first. The body and
+// the attachments are fetched only for the ones that are not read in
+// state, or for all of them when state is nil. When only some of the
+// courses fail, the announcements of the rest are returned along with an
+// error listing the failures.
+func Get(
+	ctx context.Context,
+	opts *config.Options,
+	courses []course.Course,
+	state *State,
+	c *colly.Collector,
+) ([]Announcement, error) {
+	err := c.Limit(&colly.LimitRule{DomainGlob: "*", Delay: opts.RequestDelay})
+	if err != nil {
+		return nil, err
+	}
+
+	announcements := make([]Announcement, 0, 10)
+	var failures []string
+
+	for i := range courses {
+		apc, err := FetchCourse(ctx, opts, &courses[i], state, c.Clone())
+		if err = ctxErr(ctx, err); err != nil {
+			failures = append(failures, fmt.Sprintf("course %v: %v", courses[i].ID, err))
+			continue
+		}
+		announcements = append(announcements, apc...)
+	}
+
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(announcements, func(i, j int) bool {
+		return announcements[i].Date.After(announcements[j].Date)
+	})
+
+	if len(failures) > 0 {
+		err = fmt.Errorf(
+			"failed to fetch announcements of %v course(s):\n%v",
+			len(failures),
+			strings.Join(failures, "\n"),
+		)
+		if len(failures) == len(courses) {
+			return nil, err
+		}
+	}
+	return announcements, err
+}
+
+func ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// FetchCourse fetches the announcements of a single course, along with the
+// body and the attachments of the ones that are not read in state, or of
+// all of them when state is nil.
+func FetchCourse(
+	ctx context.Context,
+	opts *config.Options,
+	crs *course.Course,
+	state *State,
+	c *colly.Collector,
+) ([]Announcement, error) {
+	announcements := make([]Announcement, 0, 10)
+
+	c.OnError(func(r *colly.Response, err error) {
+		log.Println("Request URL:", r.Request.URL,
+			"failed with response:", r, "\nError:", err)
+	})
+
+	c.OnHTML("#main-content table tbody tr", func(h *colly.HTMLElement) {
+		link := h.DOM.Find("a[href*='an_id=']").First()
+		href, ok := link.Attr("href")
+		if !ok {
+			return
+		}
+
+		announcementURL := h.Request.AbsoluteURL(href)
+		id, err := parseID(announcementURL)
+		if err != nil {
+			return
+		}
+
+		a := Announcement{
+			ID:     id,
+			Course: crs,
+			Title:  strings.TrimSpace(link.Text()),
+			URL:    announcementURL,
+		}
+		if date, err := assignment.ParseDate(h.DOM.Find("td").Last().Text()); err == nil {
+			a.Date = date
+		}
+
+		announcements = append(announcements, a)
+	})
+
+	finalURL, err := crs.PrepareAnnouncementsURL(opts.BaseDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.Visit("https://" + finalURL)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range announcements {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		if state != nil && state.IsRead(&announcements[i]) {
+			continue
+		}
+
+		err = fetchBody(&announcements[i], c.Clone())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return announcements, ctx.Err()
+}
+
+// fetchBody visits the page of the announcement for its body and its
+// attachments.
+func fetchBody(a *Announcement, c *colly.Collector) error {
+	c.OnHTML("#main-content", func(h *colly.HTMLElement) {
+		body := h.DOM.Find(".announcement-main").First()
+		if body.Length() == 0 {
+			return
+		}
+		a.Body = strings.TrimSpace(body.Text())
+
+		// files are either attached or linked from the documents of the
+		// course in the body
+		h.DOM.Find(".announcement-attachments a[href], .announcement-main a[href*='file.php']").
+			Each(func(_ int, link *goquery.Selection) {
+				href, _ := link.Attr("href")
+				name := strings.TrimSpace(link.Text())
+				if name == "" {
+					return
+				}
+				a.Attachments = append(a.Attachments, assignment.Attachment{
+					Name: name,
+					URL:  h.Request.AbsoluteURL(href),
+				})
+			})
+
+		if a.Date.IsZero() {
+			date, err := assignment.ParseDate(h.DOM.Find(".announcement-date").First().Text())
+			if err == nil {
+				a.Date = date
+			}
+		}
+	})
+
+	return c.Visit(a.URL)
+}
+
+func parseID(announcementURL string) (string, error) {
+	u, err := url.Parse(announcementURL)
+	if err != nil {
+		return "", err
+	}
+
+	id := u.Query().Get("an_id")
+	if id == "" {
+		return "", fmt.Errorf("no announcement ID in %v", announcementURL)
+	}
+	return id, nil
+}
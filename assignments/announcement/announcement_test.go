@@ -0,0 +1,155 @@
+package announcement
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/internal/testserver"
+	"github.com/gocolly/colly"
+)
+
+const listPage = `<html><body><div id="main-content">
+<table class="table-default"><tbody>
+<tr><td><a href="index.php?course=ICE262&an_id=12">Αλλαγή προθεσμίας</a></td><td>20-12-2022</td></tr>
+<tr><td><a href="index.php?course=ICE262&an_id=10">Καλωσόρισμα</a></td><td>01-10-2022</td></tr>
+</tbody></table>
+</div></body></html>`
+
+const announcementPage = `<html><body><div id="main-content">
+<div class="single_announcement">
+	<div class="announcement-date">%[2]v</div>
+	<div class="announcement-main">
+		<p>Η προθεσμία της άσκησης %[1]v μετατίθεται.</p>
+		<a href="../../modules/document/file.php/ICE262/ekfonisi.pdf">ekfonisi.pdf</a>
+		<a href="https://example.org">example.org</a>
+	</div>
+</div>
+</div></body></html>`
+
+func TestFetchCourse(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			id := r.URL.Query().Get("an_id")
+			if id == "" {
+				fmt.Fprint(w, listPage)
+				return
+			}
+			fmt.Fprintf(w, announcementPage, id, "20-12-2022")
+		},
+	))
+	defer server.Close()
+
+	c := colly.NewCollector()
+	c.WithTransport(testserver.Transport(server))
+
+	opts := &config.Options{BaseDomain: "example.com"}
+	crs := &course.Course{ID: "ICE262"}
+
+	// Act
+	announcements, err := FetchCourse(context.Background(), opts, crs, nil, c)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(announcements) != 2 {
+		t.Fatalf("Expected: %v, Actual: %v", 2, len(announcements))
+	}
+
+	a := announcements[0]
+	if a.ID != "12" || a.Title != "Αλλαγή προθεσμίας" {
+		t.Errorf("Expected: %v, Actual: %v", "12 Αλλαγή προθεσμίας", a.ID+" "+a.Title)
+	}
+	if a.Body == "" {
+		t.Errorf("Expected a body, Actual: %q", a.Body)
+	}
+	if a.Date.Format("2006-01-02") != "2022-12-20" {
+		t.Errorf("Expected: %v, Actual: %v", "2022-12-20", a.Date)
+	}
+
+	expectedURL := "https://example.com/modules/document/file.php/ICE262/ekfonisi.pdf"
+	if len(a.Attachments) != 1 || a.Attachments[0].URL != expectedURL {
+		t.Errorf("Expected: %v, Actual: %v", expectedURL, a.Attachments)
+	}
+}
+
+func TestFetchCourse_ReadWithoutBody(t *testing.T) {
+	// Arrange
+	var visited []string
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			id := r.URL.Query().Get("an_id")
+			if id == "" {
+				fmt.Fprint(w, listPage)
+				return
+			}
+			visited = append(visited, id)
+			fmt.Fprintf(w, announcementPage, id, "20-12-2022")
+		},
+	))
+	defer server.Close()
+
+	c := colly.NewCollector()
+	c.WithTransport(testserver.Transport(server))
+
+	opts := &config.Options{BaseDomain: "example.com"}
+	crs := &course.Course{ID: "ICE262"}
+	state := &State{Read: map[string]time.Time{"ICE262/10": time.Now()}}
+
+	// Act
+	announcements, err := FetchCourse(context.Background(), opts, crs, state, c)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(announcements) != 2 {
+		t.Fatalf("Expected: %v, Actual: %v", 2, len(announcements))
+	}
+	if len(visited) != 1 || visited[0] != "12" {
+		t.Errorf("Expected: %v, Actual: %v", []string{"12"}, visited)
+	}
+	if announcements[0].Body == "" {
+		t.Errorf("Expected a body, Actual: %q", announcements[0].Body)
+	}
+	if announcements[1].Body != "" {
+		t.Errorf("Expected: %q, Actual: %q", "", announcements[1].Body)
+	}
+}
+
+func TestStateUnread(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "announcements.json")
+	crs := &course.Course{ID: "ICE262"}
+	first := Announcement{ID: "10", Course: crs}
+	second := Announcement{ID: "12", Course: crs}
+
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	state.MarkRead([]Announcement{first}, time.Now())
+	if err = state.Save(path); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Act
+	state, err = LoadState(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	unread := state.Unread([]Announcement{second, first})
+
+	// Assert
+	if len(unread) != 1 || unread[0].ID != "12" {
+		t.Errorf("Expected: %v, Actual: %v", "[12]", unread)
+	}
+}
@@ -0,0 +1,85 @@
+package announcement
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+)
+
+// State remembers the announcements that were already shown, so that the
+// next run only reports the unread ones.
+type State struct {
+	// Read maps the Key of every read announcement to when it was read.
+	Read map[string]time.Time `json:"read"`
+}
+
+// StatePath is the file in the cache directory that keeps the State.
+func StatePath() (string, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "announcements.json"), nil
+}
+
+// LoadState reads the state at path. A missing file is an empty state,
+// where every announcement is unread.
+func LoadState(path string) (*State, error) {
+	state := &State{Read: make(map[string]time.Time)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Read == nil {
+		state.Read = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// Save writes the state to path.
+func (s *State) Save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// IsRead reports whether the announcement was marked as read.
+func (s *State) IsRead(a *Announcement) bool {
+	_, ok := s.Read[a.Key()]
+	return ok
+}
+
+// Unread returns the announcements that were not marked as read,
+// preserving their order.
+func (s *State) Unread(announcements []Announcement) []Announcement {
+	unread := make([]Announcement, 0, len(announcements))
+	for _, a := range announcements {
+		if !s.IsRead(&a) {
+			unread = append(unread, a)
+		}
+	}
+	return unread
+}
+
+// MarkRead marks the announcements as read at now.
+func (s *State) MarkRead(announcements []Announcement, now time.Time) {
+	for _, a := range announcements {
+		if _, ok := s.Read[a.Key()]; !ok {
+			s.Read[a.Key()] = now
+		}
+	}
+}
@@ -12,12 +12,70 @@ import (
 	"github.com/gocolly/colly"
 )
 
+// Kind tells the assignments of the work module apart from the online
+// exercises (quizzes) of the exercise module.
+type Kind string
+
+const (
+	KindAssignment Kind = "assignment"
+	KindExercise   Kind = "exercise"
+)
+
 type Assignment struct {
 	ID       string
+	Kind     Kind
 	Course   *course.Course
 	Title    string
 	Deadline time.Time
 	IsSent   bool
+	// Manual is set for the assignments added to the config file by hand.
+	Manual bool
+	// URL is the link of a manual assignment, if any.
+	URL string
+
+	// The fields below are only filled in when details are fetched, see
+	// FetchDetails.
+	Description    string
+	Attachments    []Attachment
+	MaxGrade       string
+	GroupWork      bool
+	SubmittedAt    time.Time
+	SubmittedFiles []Attachment
+
+	// Grade is empty until the assignment is graded. It is read from the
+	// list of a course's assignments, while the feedback and the grading
+	// date only come with the details.
+	Grade    string
+	GradedAt time.Time
+	Feedback string
+
+	// The fields below are only set for exercises, whose Deadline is the
+	// end of their time window and IsSent whether they have been attempted.
+	Start time.Time
+	// AttemptsAllowed is 0 when the attempts are unlimited.
+	AttemptsAllowed int
+	AttemptsUsed    int
+}
+
+// Key identifies the assignment across courses by the course and the
+// assignment ID. Exercises have keys of their own, as their IDs may repeat
+// the ones of the assignments of the course.
+func (a *Assignment) Key() string {
+	if a.IsExercise() {
+		return a.Course.ID + "/" + string(KindExercise) + "/" + a.ID
+	}
+	return a.Course.ID + "/" + a.ID
+}
+
+// IsExercise reports whether a is an online exercise rather than an
+// assignment.
+func (a *Assignment) IsExercise() bool {
+	return a.Kind == KindExercise
+}
+
+// IsGraded reports whether the assignment has a grade.
+func (a *Assignment) IsGraded() bool {
+	return a.Grade != ""
 }
 
 func (a *Assignment) String() string {
@@ -38,8 +96,19 @@ func (a sortable) Len() int {
 	return len(a)
 }
 
+// Less orders by deadline, then by course and assignment ID, so that the
+// order does not depend on the order the courses were fetched in.
 func (a sortable) Less(i, j int) bool {
-	return a[i].Deadline.Before(a[j].Deadline)
+	if !a[i].Deadline.Equal(a[j].Deadline) {
+		return a[i].Deadline.Before(a[j].Deadline)
+	}
+	if a[i].Course.ID != a[j].Course.ID {
+		return a[i].Course.ID < a[j].Course.ID
+	}
+	if a[i].ID != a[j].ID {
+		return a[i].ID < a[j].ID
+	}
+	return a[i].Kind < a[j].Kind
 }
 
 func (a sortable) Swap(i, j int) {
@@ -62,10 +131,12 @@ func newAssignment(
 
 	return Assignment{
 		ID:       id,
+		Kind:     KindAssignment,
 		Course:   course,
 		Title:    strings.TrimSpace(tds[0].Text),
 		Deadline: deadline,
 		IsSent:   parseIsSent(tds[2]),
+		Grade:    parseGradeColumn(tds),
 	}, nil
 }
 
@@ -97,6 +168,25 @@ func parseIsSent(h *colly.HTMLElement) bool {
 	return h.DOM.Children().First().HasClass("fa-check-square-o")
 }
 
+// parseGradeColumn reads the grade of the fourth column, which is missing
+// from some versions of e-class.
+func parseGradeColumn(tds []*colly.HTMLElement) string {
+	if len(tds) < 4 {
+		return ""
+	}
+	return parseGrade(tds[3].Text)
+}
+
+// parseGrade returns the grade in text, or nothing for the placeholders of
+// e-class for assignments that are not graded yet.
+func parseGrade(text string) string {
+	grade := strings.TrimSpace(text)
+	if grade == "-" || grade == "—" {
+		return ""
+	}
+	return grade
+}
+
 func sortAssignments(a sortable) {
 	sort.Sort(a)
 }
@@ -104,6 +194,10 @@ func sortAssignments(a sortable) {
 func (a *Assignment) PrepareURL(
 	baseURL string,
 ) (string, error) {
+	if a.IsExercise() {
+		return a.prepareExerciseURL(baseURL)
+	}
+
 	finalURL, err := url.Parse(baseURL + "/modules/work/index.php")
 	if err != nil {
 		return "", err
@@ -116,3 +210,19 @@ func (a *Assignment) PrepareURL(
 
 	return finalURL.String(), nil
 }
+
+// FullURL returns the link of the assignment: the given URL of a manual
+// assignment, or its page on e-class otherwise. Manual assignments without
+// a URL have none.
+func (a *Assignment) FullURL(baseDomain string) (string, error) {
+	if a.Manual {
+		return a.URL, nil
+	}
+
+	assignmentURL, err := a.PrepareURL(baseDomain)
+	if err != nil {
+		return "", err
+	}
+
+	return "https://" + assignmentURL, nil
+}
@@ -1,111 +1,176 @@
 package assignment
 
 import (
-	"fmt"
+	"context"
 	"log"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/Huray-hub/eclass-utils/assignments/config"
 	"github.com/Huray-hub/eclass-utils/assignments/course"
 	"github.com/Huray-hub/eclass-utils/assignments/login"
+	"github.com/Huray-hub/eclass-utils/assignments/session"
 	"github.com/gocolly/colly"
 )
 
-var location *time.Location
+// Location is the time zone of e-class, in which it shows every date.
+var Location *time.Location
 
 func init() {
 	var err error
-	location, err = time.LoadLocation("Europe/Athens")
+	Location, err = time.LoadLocation("Europe/Athens")
 	if err != nil {
 		log.Fatalf(err.Error())
 	}
 }
 
+// Get logs in and fetches the assignments of all the enrolled courses,
+// along with the manual ones of opts, sorted by deadline. When only some
+// of the courses fail, the assignments of the rest are returned along
+// with a *PartialError.
 func Get(opts *config.Options, creds *config.Credentials) ([]Assignment, error) {
-	c := colly.NewCollector(
-		colly.AllowedDomains(opts.BaseDomain),
-	)
+	return GetContext(context.Background(), opts, creds)
+}
 
-	c.OnError(func(r *colly.Response, err error) {
-		fmt.Println("Request URL:", r.Request.URL,
-			"failed with response:", r, "\nError:", err)
-	})
+// GetContext is Get, cancelled along with ctx.
+func GetContext(
+	ctx context.Context,
+	opts *config.Options,
+	creds *config.Credentials,
+) ([]Assignment, error) {
+	s := session.New(opts.BaseDomain, nil)
 
-	err := login.Login(opts.BaseDomain, *creds, c)
+	sessionPath, err := session.DefaultPath()
 	if err != nil {
 		return nil, err
 	}
 
-	courses, err := course.Get(opts, c.Clone())
+	err = login.ResumeSession(ctx, s, sessionPath, *creds)
 	if err != nil {
 		return nil, err
 	}
 
-	assignments, err := getAssignments(opts, courses, c.Clone())
+	c := s.Collector(ctx)
+
+	c.OnError(func(r *colly.Response, err error) {
+		log.Println("Request URL:", r.Request.URL,
+			"failed with response:", r, "\nError:", err)
+	})
+
+	courses, err := course.Get(ctx, opts, c.Clone())
 	if err != nil {
 		return nil, err
 	}
 
-	return assignments, nil
+	assignments, err := FetchCourses(ctx, opts, courses, c)
+	if assignments == nil {
+		return nil, err
+	}
+
+	merged, mergeErr := MergeManual(assignments, courses, opts)
+	if mergeErr != nil {
+		return nil, mergeErr
+	}
+
+	return merged, err
+}
+
+func parallelism(opts *config.Options) int {
+	if opts.Parallelism < 1 {
+		return 4
+	}
+	return opts.Parallelism
 }
 
-func getAssignments(
-	opts *config.Options, courses []course.Course, c *colly.Collector,
+// FetchCourses fetches the assignments of the courses concurrently, with
+// at most opts.Parallelism courses in flight and opts.RequestDelay between
+// requests, and sorts them by deadline. When only some of the courses
+// fail, the assignments of the rest are returned along with a
+// *PartialError.
+func FetchCourses(
+	ctx context.Context,
+	opts *config.Options,
+	courses []course.Course,
+	c *colly.Collector,
 ) ([]Assignment, error) {
-	assignments := make(sortable, 0, len(courses))
+	err := c.Limit(&colly.LimitRule{
+		DomainGlob:  "*",
+		Parallelism: parallelism(opts),
+		Delay:       opts.RequestDelay,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	for _, crs := range courses {
-		var excludedStrings []string
-		if val, ok := opts.ExcludedAssignments[crs.ID]; ok {
-			excludedStrings = val
-		}
-		apc, err := getAssignmentsPerCourse(
-			opts,
-			excludedStrings,
-			crs,
-			c.Clone(),
-		)
-		if err != nil {
-			return nil, err
+	type result struct {
+		assignments []Assignment
+		err         error
+	}
+
+	results := make([]result, len(courses))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism(opts) && w < len(courses); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				apc, err := FetchCourse(ctx, opts, courses[i], c.Clone())
+				results[i] = result{assignments: apc, err: err}
+			}
+		}()
+	}
+
+	for i := range courses {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	assignments := make(sortable, 0, len(courses))
+	var partial PartialError
+
+	for i, res := range results {
+		if res.err != nil {
+			partial.Errors = append(
+				partial.Errors,
+				&CourseError{Course: courses[i], Err: res.err},
+			)
+			continue
 		}
-		assignments = append(assignments, apc...)
+		assignments = append(assignments, res.assignments...)
 	}
 
 	sortAssignments(assignments)
+
+	if len(partial.Errors) == len(courses) && len(courses) > 0 {
+		return nil, &partial
+	}
+	if len(partial.Errors) > 0 {
+		return assignments, &partial
+	}
 	return assignments, nil
 }
 
-func getAssignmentsPerCourse(
+// FetchCourse fetches the assignments and the online exercises of a single
+// course, leaving out the ones excluded by opts, along with the details of
+// the assignments if opts.FetchDetails is set.
+func FetchCourse(
+	ctx context.Context,
 	opts *config.Options,
-	excludedStrings []string,
 	course course.Course,
 	c *colly.Collector,
 ) ([]Assignment, error) {
 	assignments := make([]Assignment, 0, 10)
 
-	isExcluded := func(a Assignment) bool {
-		if !opts.IncludeExpired && a.Deadline.Before(time.Now().In(location)) {
-			return true
-		}
-
-		if excludedStrings == nil {
-			return false
-		}
-
-		for _, v := range excludedStrings {
-			if strings.Contains(a.Title, v) {
-				return true
-			}
-		}
-
-		return false
-	}
-
 	c.OnError(func(r *colly.Response, err error) {
-		fmt.Println("Request URL:", r.Request.URL,
+		log.Println("Request URL:", r.Request.URL,
 			"failed with response:", r, "\nError:", err)
-		log.Fatal(err.Error())
 	})
 
 	c.OnHTML(
@@ -120,12 +185,12 @@ func getAssignmentsPerCourse(
 				tds = append(tds, h2)
 			})
 
-			assignment, err := newAssignment(tds, &course, location)
+			assignment, err := newAssignment(tds, &course, Location)
 			if err != nil {
 				return
 			}
 
-			if isExcluded(assignment) {
+			if IsExcluded(opts, assignment, time.Now().In(Location)) {
 				return
 			}
 
@@ -143,5 +208,23 @@ func getAssignmentsPerCourse(
 		return nil, err
 	}
 
-	return assignments, nil
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if opts.FetchDetails {
+		for i := range assignments {
+			err = FetchDetails(ctx, opts, &assignments[i], c.Clone())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	exercises, err := FetchExercises(ctx, opts, &course, c.Clone())
+	if err != nil {
+		return nil, err
+	}
+
+	return append(assignments, exercises...), nil
 }
@@ -0,0 +1,76 @@
+package assignment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/internal/testserver"
+	"github.com/gocolly/colly"
+)
+
+const workPage = `<html><body>
+<table id="assignment_table"><tbody>
+<tr>
+	<td><a href="index.php?course=%[1]v&id=%[2]v">Άσκηση %[2]v</a></td>
+	<td>Τετάρτη 21 Δεκεμβρίου 2022 - 11:59 μ.μ.(απομένουν 19 ημέρες 3 ώρες 8 λεπτά)</td>
+	<td><i class="fa fa-square-o"></i></td>
+</tr>
+</tbody></table>
+</body></html>`
+
+func TestGetAssignments_PartialFailure(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			courseID := r.URL.Query().Get("course")
+			if courseID == "BROKEN" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprintf(w, workPage, courseID, len(courseID))
+		},
+	))
+	defer server.Close()
+
+	c := colly.NewCollector()
+	c.WithTransport(testserver.Transport(server))
+
+	opts := &config.Options{
+		BaseDomain:     "example.com",
+		IncludeExpired: true,
+		Parallelism:    2,
+	}
+	courses := []course.Course{
+		{ID: "CS152", Name: "Αλγόριθμοι"},
+		{ID: "BROKEN", Name: "Σπασμένο"},
+		{ID: "ICE262", Name: "Ανάκτηση Πληροφορίας"},
+	}
+
+	// Act
+	assignments, err := FetchCourses(context.Background(), opts, courses, c)
+
+	// Assert
+	partial, ok := err.(*PartialError)
+	if !ok {
+		t.Fatalf("Expected a *PartialError, Actual: %v", err)
+	}
+	if len(partial.Errors) != 1 || partial.Errors[0].Course.ID != "BROKEN" {
+		t.Errorf("Expected only BROKEN to fail, Actual: %v", partial)
+	}
+
+	if len(assignments) != 2 {
+		t.Fatalf("Expected 2 assignments, Actual: %v", len(assignments))
+	}
+	if assignments[0].Course.ID != "CS152" || assignments[1].Course.ID != "ICE262" {
+		t.Errorf(
+			"Expected deterministic order CS152, ICE262, Actual: %v, %v",
+			assignments[0].Course.ID,
+			assignments[1].Course.ID,
+		)
+	}
+}
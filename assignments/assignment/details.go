@@ -0,0 +1,152 @@
+package assignment
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/text"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// Attachment is a file linked from an assignment's page.
+type Attachment struct {
+	Name string
+	URL  string
+}
+
+// FetchDetails visits the assignment's own page and fills in the fields
+// that the list of a course's assignments lacks.
+func FetchDetails(
+	ctx context.Context,
+	opts *config.Options,
+	a *Assignment,
+	c *colly.Collector,
+) error {
+	c.OnHTML("html", func(h *colly.HTMLElement) {
+		parseDetails(h.DOM, a, h.Request.AbsoluteURL)
+	})
+
+	assignmentURL, err := a.PrepareURL(opts.BaseDomain)
+	if err != nil {
+		return err
+	}
+
+	err = c.Visit("https://" + assignmentURL)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+// parseDetails reads the label-value rows of the assignment's page. The
+// first panel with such rows describes the assignment and the rest the
+// submission.
+func parseDetails(doc *goquery.Selection, a *Assignment, absoluteURL func(string) string) {
+	isInfo := true
+
+	doc.Find(".panel").Each(func(_ int, panel *goquery.Selection) {
+		found := false
+
+		panel.Find(".row, tr").Each(func(_ int, row *goquery.Selection) {
+			label := row.Find(".col-sm-3, th").First()
+			value := row.Find(".col-sm-9, td").First()
+			if label.Length() == 0 || value.Length() == 0 {
+				return
+			}
+			found = true
+			setDetail(a, text.Normalize(label.Text()), value, isInfo, absoluteURL)
+		})
+
+		if found {
+			isInfo = false
+		}
+	})
+}
+
+func setDetail(
+	a *Assignment,
+	label string,
+	value *goquery.Selection,
+	isInfo bool,
+	absoluteURL func(string) string,
+) {
+	content := strings.TrimSpace(value.Text())
+	is := func(name string) bool {
+		return strings.Contains(label, text.Normalize(name))
+	}
+
+	switch {
+	case is("περιγραφή"):
+		a.Description = content
+	case is("μέγιστη βαθμολογία"):
+		a.MaxGrade = content
+	case is("τύπος εργασίας"):
+		a.GroupWork = strings.Contains(text.Normalize(content), text.Normalize("ομαδική"))
+	case isInfo && (is("βαθμός") || is("σχόλια") || is("ημερομηνία")):
+		// the submission and its grading are only in the rest of the panels
+		return
+	case is("βαθμός"):
+		if grade := parseGrade(content); grade != "" {
+			a.Grade = grade
+		}
+	case is("σχόλια βαθμολογητή") || is("σχόλια διδάσκοντα"):
+		a.Feedback = content
+	case is("ημερομηνία βαθμολόγησης"):
+		if t, err := ParseDate(content); err == nil {
+			a.GradedAt = t
+		}
+	case is("ημερομηνία"):
+		if t, err := ParseDate(content); err == nil {
+			a.SubmittedAt = t
+		}
+	case is("αρχεί"):
+		attachments := parseAttachments(value, absoluteURL)
+		if isInfo {
+			a.Attachments = append(a.Attachments, attachments...)
+		} else {
+			a.SubmittedFiles = append(a.SubmittedFiles, attachments...)
+		}
+	}
+}
+
+func parseAttachments(value *goquery.Selection, absoluteURL func(string) string) []Attachment {
+	attachments := make([]Attachment, 0, 1)
+
+	value.Find("a[href]").Each(func(_ int, link *goquery.Selection) {
+		href, _ := link.Attr("href")
+		name := strings.TrimSpace(link.Text())
+		if href == "" || name == "" {
+			return
+		}
+		attachments = append(attachments, Attachment{Name: name, URL: absoluteURL(href)})
+	})
+
+	return attachments
+}
+
+// ParseDate parses a date of an e-class page in Greek local time, written
+// either in words like the deadlines or in numbers.
+func ParseDate(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	for _, layout := range []string{
+		"02-01-2006 15:04:05",
+		"02-01-2006 15:04",
+		"02/01/2006 15:04",
+		"02-01-2006",
+		"02/01/2006",
+	} {
+		if t, err := time.ParseInLocation(layout, raw, Location); err == nil {
+			return t, nil
+		}
+	}
+
+	t, err := parseTime(raw, Location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return *t, nil
+}
@@ -0,0 +1,115 @@
+package assignment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/internal/testserver"
+	"github.com/gocolly/colly"
+)
+
+const detailsPage = `<html><body>
+<div class="panel panel-action-btn-primary">
+	<div class="panel-heading"><h3 class="panel-title">Στοιχεία εργασίας</h3></div>
+	<div class="panel-body">
+		<div class="row margin-bottom-fat">
+			<div class="col-sm-3"><strong>Τίτλος:</strong></div>
+			<div class="col-sm-9">Άσκηση 1</div>
+		</div>
+		<div class="row margin-bottom-fat">
+			<div class="col-sm-3"><strong>Περιγραφή:</strong></div>
+			<div class="col-sm-9"><p>Υλοποίηση του <b>BM25</b>.</p></div>
+		</div>
+		<div class="row margin-bottom-fat">
+			<div class="col-sm-3"><strong>Αρχείο:</strong></div>
+			<div class="col-sm-9"><a href="index.php?course=%[1]v&get=%[2]v&file_type=1">ekfonisi.pdf</a></div>
+		</div>
+		<div class="row margin-bottom-fat">
+			<div class="col-sm-3"><strong>Μέγιστη βαθμολογία:</strong></div>
+			<div class="col-sm-9">10</div>
+		</div>
+		<div class="row margin-bottom-fat">
+			<div class="col-sm-3"><strong>Τύπος εργασίας:</strong></div>
+			<div class="col-sm-9">Ομαδική εργασία</div>
+		</div>
+	</div>
+</div>
+<div class="panel panel-default">
+	<div class="panel-body">
+		<table class="table-default">
+			<tr><th>Ημερομηνία αποστολής:</th><td>20-12-2022 18:30:05</td></tr>
+			<tr><th>Όνομα αρχείου:</th><td><a href="index.php?course=%[1]v&get=9001">lysi.zip</a></td></tr>
+			<tr><th>Βαθμός:</th><td>8,5</td></tr>
+			<tr><th>Ημερομηνία βαθμολόγησης:</th><td>10-01-2023 12:00</td></tr>
+			<tr><th>Σχόλια βαθμολογητή:</th><td>Καλή δουλειά.</td></tr>
+		</table>
+	</div>
+</div>
+</body></html>`
+
+func TestFetchDetails(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, detailsPage, r.URL.Query().Get("course"), r.URL.Query().Get("id"))
+		},
+	))
+	defer server.Close()
+
+	c := colly.NewCollector()
+	c.WithTransport(testserver.Transport(server))
+
+	opts := &config.Options{BaseDomain: "example.com"}
+	a := Assignment{ID: "24692", Course: &course.Course{ID: "ICE262"}}
+
+	// Act
+	err := FetchDetails(context.Background(), opts, &a, c)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if a.Description != "Υλοποίηση του BM25." {
+		t.Errorf("Expected: %v, Actual: %v", "Υλοποίηση του BM25.", a.Description)
+	}
+	if a.MaxGrade != "10" {
+		t.Errorf("Expected: %v, Actual: %v", "10", a.MaxGrade)
+	}
+	if !a.GroupWork {
+		t.Errorf("Expected: %v, Actual: %v", true, a.GroupWork)
+	}
+
+	expectedAttachment := Attachment{
+		Name: "ekfonisi.pdf",
+		URL:  "https://example.com/modules/work/index.php?course=ICE262&get=24692&file_type=1",
+	}
+	if len(a.Attachments) != 1 || a.Attachments[0] != expectedAttachment {
+		t.Errorf("Expected: %v, Actual: %v", expectedAttachment, a.Attachments)
+	}
+
+	expectedSubmittedAt := time.Date(2022, 12, 20, 18, 30, 5, 0, Location)
+	if !a.SubmittedAt.Equal(expectedSubmittedAt) {
+		t.Errorf("Expected: %v, Actual: %v", expectedSubmittedAt, a.SubmittedAt)
+	}
+	if len(a.SubmittedFiles) != 1 || a.SubmittedFiles[0].Name != "lysi.zip" {
+		t.Errorf("Expected: %v, Actual: %v", "lysi.zip", a.SubmittedFiles)
+	}
+
+	if a.Grade != "8,5" {
+		t.Errorf("Expected: %v, Actual: %v", "8,5", a.Grade)
+	}
+	expectedGradedAt := time.Date(2023, 1, 10, 12, 0, 0, 0, Location)
+	if !a.GradedAt.Equal(expectedGradedAt) {
+		t.Errorf("Expected: %v, Actual: %v", expectedGradedAt, a.GradedAt)
+	}
+	if a.Feedback != "Καλή δουλειά." {
+		t.Errorf("Expected: %v, Actual: %v", "Καλή δουλειά.", a.Feedback)
+	}
+}
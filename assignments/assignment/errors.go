@@ -0,0 +1,41 @@
+package assignment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+// CourseError is the failure to fetch the assignments of a single course.
+type CourseError struct {
+	Course course.Course
+	Err    error
+}
+
+func (e *CourseError) Error() string {
+	return fmt.Sprintf("course %v (%v): %v", e.Course.ID, e.Course.Name, e.Err)
+}
+
+func (e *CourseError) Unwrap() error {
+	return e.Err
+}
+
+// PartialError is returned along with the assignments that were fetched
+// when some of the courses failed.
+type PartialError struct {
+	Errors []*CourseError
+}
+
+func (e *PartialError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+
+	return fmt.Sprintf(
+		"failed to fetch assignments of %v course(s):\n%v",
+		len(e.Errors),
+		strings.Join(msgs, "\n"),
+	)
+}
@@ -0,0 +1,186 @@
+package assignment
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/text"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// attemptsPattern matches the attempts of an exercise, as "used/allowed"
+// or only the used ones.
+var attemptsPattern = regexp.MustCompile(`(\d+)(?:\s*/\s*(\d+))?`)
+
+// exerciseColumns are the positions of the columns of the exercises
+// table, found by their headers. Missing columns are -1.
+type exerciseColumns struct {
+	start    int
+	end      int
+	attempts int
+}
+
+// FetchExercises fetches the online exercises of a course that have an end
+// time, leaving out the ones excluded by opts like any assignment. A course
+// without the exercise module has none.
+func FetchExercises(
+	ctx context.Context,
+	opts *config.Options,
+	crs *course.Course,
+	c *colly.Collector,
+) ([]Assignment, error) {
+	exercises := make([]Assignment, 0, 4)
+
+	c.OnHTML("table", func(h *colly.HTMLElement) {
+		if h.DOM.Find("a[href*='exerciseId=']").Length() == 0 {
+			return
+		}
+
+		columns := findExerciseColumns(h.DOM)
+		h.DOM.Find("tr").Each(func(_ int, row *goquery.Selection) {
+			exercise, ok := newExercise(row, columns, crs)
+			if !ok || IsExcluded(opts, exercise, time.Now().In(Location)) {
+				return
+			}
+			exercises = append(exercises, exercise)
+		})
+	})
+
+	exercisesURL, err := crs.PrepareExercisesURL(opts.BaseDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	// e-class answers with 403 or 404 for the modules that are disabled in
+	// the course, as in most courses
+	var status int
+	c.OnError(func(r *colly.Response, _ error) {
+		status = r.StatusCode
+	})
+
+	err = c.Visit("https://" + exercisesURL)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if status == http.StatusNotFound || status == http.StatusForbidden {
+			log.Println("no exercises for course", crs.ID+":", err.Error())
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return exercises, ctx.Err()
+}
+
+func findExerciseColumns(table *goquery.Selection) exerciseColumns {
+	columns := exerciseColumns{start: -1, end: -1, attempts: -1}
+
+	table.Find("th").Each(func(i int, th *goquery.Selection) {
+		header := text.Normalize(th.Text())
+		switch {
+		case strings.Contains(header, text.Normalize("έναρξη")):
+			columns.start = i
+		case strings.Contains(header, text.Normalize("λήξη")):
+			columns.end = i
+		case strings.Contains(header, text.Normalize("προσπάθει")):
+			columns.attempts = i
+		}
+	})
+
+	return columns
+}
+
+// newExercise reads a row of the exercises table. Rows without a link to
+// an exercise or without an end time are skipped.
+func newExercise(
+	row *goquery.Selection,
+	columns exerciseColumns,
+	crs *course.Course,
+) (Assignment, bool) {
+	tds := row.Find("td")
+	link := tds.First().Find("a[href*='exerciseId=']").First()
+	href, ok := link.Attr("href")
+	if !ok || columns.end < 0 {
+		return Assignment{}, false
+	}
+
+	id := exerciseID(href)
+	if id == "" {
+		return Assignment{}, false
+	}
+
+	deadline, err := ParseDate(tds.Eq(columns.end).Text())
+	if err != nil {
+		return Assignment{}, false
+	}
+
+	exercise := Assignment{
+		ID:       id,
+		Kind:     KindExercise,
+		Course:   crs,
+		Title:    strings.TrimSpace(link.Text()),
+		Deadline: deadline,
+	}
+
+	if columns.start >= 0 {
+		if start, err := ParseDate(tds.Eq(columns.start).Text()); err == nil {
+			exercise.Start = start
+		}
+	}
+	if columns.attempts >= 0 {
+		exercise.AttemptsUsed, exercise.AttemptsAllowed = parseAttempts(tds.Eq(columns.attempts).Text())
+	}
+	exercise.IsSent = exercise.AttemptsUsed > 0
+
+	return exercise, true
+}
+
+func exerciseID(href string) string {
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+
+	id := u.Query().Get("exerciseId")
+	if _, err := strconv.Atoi(id); err != nil {
+		return ""
+	}
+	return id
+}
+
+// parseAttempts reads the used and allowed attempts of an exercise, with 0
+// allowed attempts when they are unlimited or not shown.
+func parseAttempts(text string) (used, allowed int) {
+	match := attemptsPattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0, 0
+	}
+
+	used, _ = strconv.Atoi(match[1])
+	allowed, _ = strconv.Atoi(match[2])
+	return used, allowed
+}
+
+func (a *Assignment) prepareExerciseURL(baseURL string) (string, error) {
+	finalURL, err := url.Parse(baseURL + "/modules/exercise/exercise_submit.php")
+	if err != nil {
+		return "", err
+	}
+
+	values := finalURL.Query()
+	values.Add("course", a.Course.ID)
+	values.Add("exerciseId", a.ID)
+	finalURL.RawQuery = values.Encode()
+
+	return finalURL.String(), nil
+}
@@ -0,0 +1,168 @@
+package assignment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/internal/testserver"
+	"github.com/gocolly/colly"
+)
+
+const exercisesPage = `<html><body>
+<div id="main-content">
+<table class="table-default">
+	<tr class="list-header">
+		<th>Όνομα άσκησης</th>
+		<th>Έναρξη</th>
+		<th>Λήξη</th>
+		<th>Προσπάθειες</th>
+	</tr>
+	<tr>
+		<td><a href="exercise_submit.php?course=%[1]v&amp;exerciseId=7">Quiz 1</a><br>Κεφάλαια 1-3</td>
+		<td>14-12-2022 10:00</td>
+		<td>21-12-2022 23:59</td>
+		<td>1/3</td>
+	</tr>
+	<tr>
+		<td><a href="exercise_submit.php?course=%[1]v&amp;exerciseId=8">Quiz 2</a></td>
+		<td>01-12-2022 10:00</td>
+		<td>10-12-2022 23:59</td>
+		<td>0</td>
+	</tr>
+	<tr>
+		<td><a href="exercise_submit.php?course=%[1]v&amp;exerciseId=9">Εξάσκηση</a></td>
+		<td>01-10-2022 10:00</td>
+		<td></td>
+		<td>2</td>
+	</tr>
+</table>
+</div>
+</body></html>`
+
+func TestFetchCourse_Exercises(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			courseID := r.URL.Query().Get("course")
+			if strings.Contains(r.URL.Path, "exercise") {
+				fmt.Fprintf(w, exercisesPage, courseID)
+				return
+			}
+			fmt.Fprintf(w, workPage, courseID, 7)
+		},
+	))
+	defer server.Close()
+
+	c := colly.NewCollector()
+	c.WithTransport(testserver.Transport(server))
+
+	opts := &config.Options{BaseDomain: "example.com", IncludeExpired: true}
+	crs := course.Course{ID: "ICE262", Name: "Ανάκτηση Πληροφορίας"}
+
+	// Act
+	assignments, err := FetchCourse(context.Background(), opts, crs, c)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(assignments) != 3 {
+		t.Fatalf("Expected: %v, Actual: %v", 3, len(assignments))
+	}
+
+	if assignments[0].Kind != KindAssignment || assignments[0].ID != "7" {
+		t.Errorf("Expected: %v, Actual: %v", "assignment 7", assignments[0])
+	}
+
+	quiz := assignments[1]
+	expected := Assignment{
+		ID:              "7",
+		Kind:            KindExercise,
+		Title:           "Quiz 1",
+		Deadline:        time.Date(2022, 12, 21, 23, 59, 0, 0, Location),
+		Start:           time.Date(2022, 12, 14, 10, 0, 0, 0, Location),
+		IsSent:          true,
+		AttemptsAllowed: 3,
+		AttemptsUsed:    1,
+	}
+	if quiz.ID != expected.ID || quiz.Kind != expected.Kind || quiz.Title != expected.Title {
+		t.Errorf("Expected: %v %v %v, Actual: %v %v %v",
+			expected.ID, expected.Kind, expected.Title, quiz.ID, quiz.Kind, quiz.Title)
+	}
+	if !quiz.Deadline.Equal(expected.Deadline) || !quiz.Start.Equal(expected.Start) {
+		t.Errorf("Expected: %v - %v, Actual: %v - %v",
+			expected.Start, expected.Deadline, quiz.Start, quiz.Deadline)
+	}
+	if quiz.IsSent != expected.IsSent ||
+		quiz.AttemptsAllowed != expected.AttemptsAllowed ||
+		quiz.AttemptsUsed != expected.AttemptsUsed {
+		t.Errorf("Expected: %v %v/%v, Actual: %v %v/%v",
+			expected.IsSent, expected.AttemptsUsed, expected.AttemptsAllowed,
+			quiz.IsSent, quiz.AttemptsUsed, quiz.AttemptsAllowed)
+	}
+
+	if assignments[2].ID != "8" || assignments[2].IsSent || assignments[2].AttemptsAllowed != 0 {
+		t.Errorf("Expected: %v, Actual: %v", "unattempted exercise 8", assignments[2])
+	}
+
+	exerciseURL, err := quiz.FullURL(opts.BaseDomain)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expectedURL := "https://example.com/modules/exercise/exercise_submit.php?course=ICE262&exerciseId=7"
+	if exerciseURL != expectedURL {
+		t.Errorf("Expected: %v, Actual: %v", expectedURL, exerciseURL)
+	}
+}
+
+func TestFetchCourse_NoExercisesModule(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		fails  bool
+	}{
+		{name: "not found", status: http.StatusNotFound},
+		{name: "forbidden", status: http.StatusForbidden},
+		{name: "server error", status: http.StatusServiceUnavailable, fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewTLSServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					if strings.Contains(r.URL.Path, "exercise") {
+						w.WriteHeader(tt.status)
+						return
+					}
+					fmt.Fprintf(w, workPage, r.URL.Query().Get("course"), 7)
+				},
+			))
+			defer server.Close()
+
+			c := colly.NewCollector()
+			c.WithTransport(testserver.Transport(server))
+
+			opts := &config.Options{BaseDomain: "example.com", IncludeExpired: true}
+			crs := course.Course{ID: "ICE262", Name: "Ανάκτηση Πληροφορίας"}
+
+			// Act
+			assignments, err := FetchCourse(context.Background(), opts, crs, c)
+
+			// Assert
+			if (err != nil) != tt.fails {
+				t.Fatalf("Expected failure: %v, Actual: %v", tt.fails, err)
+			}
+			if !tt.fails && len(assignments) != 1 {
+				t.Errorf("Expected: %v, Actual: %v", 1, len(assignments))
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package assignment
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/text"
+)
+
+// IsExcluded reports whether the assignment is left out by the configured
+// options: expired assignments that are not graded unless IncludeExpired is
+// set, and the courses and keywords of ExcludedCourses and
+// ExcludedAssignments.
+func IsExcluded(opts *config.Options, a Assignment, now time.Time) bool {
+	if !opts.IncludeExpired && a.Deadline.Before(now) && !a.IsGraded() {
+		return true
+	}
+
+	return opts.IsCourseExcluded(a.Course.ID) ||
+		opts.IsAssignmentExcluded(a.Course.ID, a.Title)
+}
+
+// Filter narrows down a list of assignments. The zero value keeps every
+// assignment.
+type Filter struct {
+	// Query is fuzzy matched against the course name, the course ID and
+	// the assignment title, ignoring case and Greek accents.
+	Query string
+	// NotSubmitted keeps only the assignments that are not sent yet.
+	NotSubmitted bool
+	// DueThisWeek keeps only the assignments due within the next 7 days.
+	DueThisWeek bool
+	// Expired keeps only the assignments whose deadline has passed.
+	Expired bool
+}
+
+// IsZero reports whether the filter keeps every assignment.
+func (f Filter) IsZero() bool {
+	return f == Filter{}
+}
+
+// Apply returns the assignments that pass both the exclusion rules of opts
+// and the filter, preserving their order.
+func (f Filter) Apply(
+	assignments []Assignment,
+	opts *config.Options,
+	now time.Time,
+) []Assignment {
+	terms := strings.Fields(text.Normalize(f.Query))
+
+	res := make([]Assignment, 0, len(assignments))
+	for _, a := range assignments {
+		if IsExcluded(opts, a, now) || !f.match(a, terms, now) {
+			continue
+		}
+		res = append(res, a)
+	}
+
+	return res
+}
+
+func (f Filter) match(a Assignment, terms []string, now time.Time) bool {
+	if f.NotSubmitted && a.IsSent {
+		return false
+	}
+
+	if f.DueThisWeek && (a.Deadline.Before(now) || a.Deadline.After(now.AddDate(0, 0, 7))) {
+		return false
+	}
+
+	if f.Expired && !a.Deadline.Before(now) {
+		return false
+	}
+
+	fields := []string{
+		text.Normalize(a.Course.Name),
+		text.Normalize(a.Course.ID),
+		text.Normalize(a.Title),
+	}
+
+	for _, term := range terms {
+		if !matchAny(term, fields) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchAny(term string, fields []string) bool {
+	for _, field := range fields {
+		if fuzzyMatch(term, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// fuzzyMatch reports whether the characters of pattern appear in s in the
+// same order, not necessarily next to each other.
+func fuzzyMatch(pattern, s string) bool {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return true
+	}
+
+	i := 0
+	for _, r := range s {
+		if r == p[i] {
+			i++
+			if i == len(p) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+package assignment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+func TestFilterApply(t *testing.T) {
+	// Arrange
+	now := time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC)
+	ir := &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"}
+	algo := &course.Course{ID: "CS152", Name: "Αλγόριθμοι και Πολυπλοκότητα"}
+
+	assignments := []Assignment{
+		{ID: "1", Course: ir, Title: "Άσκηση 1 (τμήματα Τετάρτης)", Deadline: now.AddDate(0, 0, -1)},
+		{ID: "2", Course: ir, Title: "Άσκηση 2 (τμήματα Δευτέρας)", Deadline: now.AddDate(0, 0, 2)},
+		{ID: "3", Course: algo, Title: "Εργασία εξαμήνου", Deadline: now.AddDate(0, 0, 3), IsSent: true},
+		{ID: "4", Course: algo, Title: "Πρόοδος", Deadline: now.AddDate(0, 0, 20)},
+	}
+
+	opts := &config.Options{
+		IncludeExpired:      true,
+		ExcludedAssignments: map[string][]string{"ICE262": {"Δευτέρας"}},
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []string
+	}{
+		{"zero value keeps all but excluded", Filter{}, []string{"1", "3", "4"}},
+		{"accent insensitive", Filter{Query: "ασκηση"}, []string{"1"}},
+		{"fuzzy course name", Filter{Query: "αλγρθμ"}, []string{"3", "4"}},
+		{"course ID", Filter{Query: "ice"}, []string{"1"}},
+		{"every term matches", Filter{Query: "cs152 προοδος"}, []string{"4"}},
+		{"not submitted", Filter{NotSubmitted: true}, []string{"1", "4"}},
+		{"due this week", Filter{DueThisWeek: true}, []string{"3"}},
+		{"expired", Filter{Expired: true}, []string{"1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			res := tt.filter.Apply(assignments, opts, now)
+
+			// Assert
+			ids := make([]string, 0, len(res))
+			for _, a := range res {
+				ids = append(ids, a.ID)
+			}
+			if len(ids) != len(tt.expected) {
+				t.Fatalf("Expected: %v, Actual: %v", tt.expected, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.expected[i] {
+					t.Fatalf("Expected: %v, Actual: %v", tt.expected, ids)
+				}
+			}
+		})
+	}
+}
+
+func TestIsExcluded_ExpiredGraded(t *testing.T) {
+	// Arrange
+	now := time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC)
+	ir := &course.Course{ID: "ICE262"}
+	opts := &config.Options{IncludeExpired: false}
+
+	tests := []struct {
+		name     string
+		a        Assignment
+		expected bool
+	}{
+		{"expired", Assignment{Course: ir, Deadline: now.AddDate(0, 0, -1)}, true},
+		{"expired and graded", Assignment{Course: ir, Deadline: now.AddDate(0, 0, -1), Grade: "8"}, false},
+		{"not expired", Assignment{Course: ir, Deadline: now.AddDate(0, 0, 1)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			res := IsExcluded(opts, tt.a, now)
+
+			// Assert
+			if res != tt.expected {
+				t.Errorf("Expected: %v, Actual: %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package assignment
+
+import "github.com/Huray-hub/eclass-utils/assignments/course"
+
+// CourseGrades are the graded assignments of a course, along with its
+// gradebook when fetched.
+type CourseGrades struct {
+	Course      course.Course
+	Assignments []Assignment
+	Gradebook   *course.Gradebook
+}
+
+// Grades groups the graded assignments by course, in the order their
+// courses first appear.
+func Grades(assignments []Assignment) []CourseGrades {
+	grades := make([]CourseGrades, 0, 10)
+	index := make(map[string]int, 10)
+
+	for _, a := range assignments {
+		if !a.IsGraded() {
+			continue
+		}
+
+		i, ok := index[a.Course.ID]
+		if !ok {
+			i = len(grades)
+			index[a.Course.ID] = i
+			grades = append(grades, CourseGrades{Course: *a.Course})
+		}
+		grades[i].Assignments = append(grades[i].Assignments, a)
+	}
+
+	return grades
+}
@@ -0,0 +1,85 @@
+package assignment
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+// ParseManualDeadline parses the deadline of a manual assignment, given in
+// Greek local time.
+func ParseManualDeadline(deadline string) (time.Time, error) {
+	return time.ParseInLocation(config.ManualDeadlineLayout, deadline, Location)
+}
+
+// newManual turns a manual assignment of the config file into an
+// Assignment of the course with the same ID, or of a course named after
+// the ID when it is not among the fetched ones.
+func newManual(
+	m config.ManualAssignment,
+	courses map[string]*course.Course,
+) (Assignment, error) {
+	deadline, err := ParseManualDeadline(m.Deadline)
+	if err != nil {
+		return Assignment{}, fmt.Errorf("manual assignment %v: %w", m.ID, err)
+	}
+
+	crs, ok := courses[m.CourseID]
+	if !ok {
+		crs = &course.Course{ID: m.CourseID, Name: m.CourseID}
+		courses[m.CourseID] = crs
+	}
+
+	return Assignment{
+		ID:       m.ID,
+		Kind:     KindAssignment,
+		Course:   crs,
+		Title:    m.Title,
+		Deadline: deadline,
+		IsSent:   m.Submitted,
+		Manual:   true,
+		URL:      m.URL,
+	}, nil
+}
+
+// MergeManual adds the manual assignments of opts to the fetched ones,
+// leaving out the ones excluded by opts like any other, and sorts them by
+// deadline. Manual assignments belong to the course of the same ID among
+// courses. Previously merged manual assignments are replaced.
+func MergeManual(
+	assignments []Assignment,
+	courses []course.Course,
+	opts *config.Options,
+) ([]Assignment, error) {
+	byID := make(map[string]*course.Course, len(courses))
+	for i := range courses {
+		byID[courses[i].ID] = &courses[i]
+	}
+
+	merged := make(sortable, 0, len(assignments)+len(opts.ManualAssignments))
+	for _, a := range assignments {
+		if a.Manual {
+			continue
+		}
+		byID[a.Course.ID] = a.Course
+		merged = append(merged, a)
+	}
+
+	now := time.Now().In(Location)
+	for _, m := range opts.ManualAssignments {
+		a, err := newManual(m, byID)
+		if err != nil {
+			return nil, err
+		}
+
+		if IsExcluded(opts, a, now) {
+			continue
+		}
+		merged = append(merged, a)
+	}
+
+	sortAssignments(merged)
+	return merged, nil
+}
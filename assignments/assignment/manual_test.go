@@ -0,0 +1,60 @@
+package assignment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+func TestMergeManual(t *testing.T) {
+	// Arrange
+	courses := []course.Course{
+		{ID: "CS152", Name: "Αλγόριθμοι"},
+		{ID: "ICE262", Name: "Ανάκτηση Πληροφορίας"},
+	}
+	now := time.Now().In(Location)
+	fetched := []Assignment{
+		{ID: "1", Course: &courses[0], Title: "Εργασία 1", Deadline: now.AddDate(0, 0, 2)},
+	}
+
+	opts := &config.Options{
+		ManualAssignments: []config.ManualAssignment{
+			{
+				ID:       "m1",
+				CourseID: "ICE262",
+				Title:    "Παρουσίαση",
+				Deadline: now.AddDate(0, 0, 1).Format(config.ManualDeadlineLayout),
+				URL:      "https://teams.microsoft.com/",
+			},
+			{
+				ID:       "m2",
+				CourseID: "CS152",
+				Title:    "Ληγμένη",
+				Deadline: now.AddDate(0, 0, -1).Format(config.ManualDeadlineLayout),
+			},
+		},
+	}
+
+	// Act
+	merged, err := MergeManual(fetched, courses, opts)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(merged) != 2 {
+		t.Fatalf("Expected the expired manual assignment to be excluded, Actual: %v", merged)
+	}
+
+	manual := merged[0]
+	if !manual.Manual || manual.ID != "m1" || manual.Course != &courses[1] {
+		t.Errorf("Expected m1 of ICE262 sorted first, Actual: %+v", manual)
+	}
+
+	url, err := manual.FullURL("eclass.uniwa.gr")
+	if err != nil || url != "https://teams.microsoft.com/" {
+		t.Errorf("Expected the manual URL, Actual: %v, %v", url, err)
+	}
+}
@@ -0,0 +1,337 @@
+// Package bot answers questions about the assignments in chat rooms and
+// posts the notifications of the watch command there. The chat protocol is
+// left to an Adapter, ex. the one of package telegram.
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/login"
+	"github.com/Huray-hub/eclass-utils/assignments/text"
+	"github.com/Huray-hub/eclass-utils/assignments/watch"
+)
+
+// retryDelay is the wait before receiving again after a failure.
+const retryDelay = 5 * time.Second
+
+// defaultCacheFor is how long the assignments of a fetch are reused, unless
+// set otherwise.
+const defaultCacheFor = time.Minute
+
+// Message is a text message received in a chat.
+type Message struct {
+	Chat string
+	Text string
+}
+
+// Adapter connects the bot to a chat protocol.
+type Adapter interface {
+	// Receive waits for the next messages, until ctx is cancelled.
+	Receive(ctx context.Context) ([]Message, error)
+	// Send posts a text message to the chat.
+	Send(ctx context.Context, chat, text string) error
+	// Name returns the username of the bot, which commands may be
+	// addressed to, as /deadlines@name.
+	Name(ctx context.Context) (string, error)
+}
+
+// Bot answers the commands sent in its chats with the assignments.
+//
+//	/deadlines       the upcoming deadlines
+//	/unsent          the upcoming deadlines not submitted yet
+//	/course CS152    the upcoming deadlines of a course, by code or name
+//	/help            the commands
+//
+// Other commands, and commands addressed to other bots, are ignored. It is
+// also a watch.Notifier that posts the changes to its chats.
+type Bot struct {
+	Adapter Adapter
+	// Name is the username of the bot. Run gets it from the Adapter when
+	// not set.
+	Name string
+	// Fetch gets the assignments for the commands, ex. through
+	// assignment.GetContext.
+	Fetch func(ctx context.Context) ([]assignment.Assignment, error)
+	// CacheFor is how long the assignments of a fetch are reused by later
+	// commands, a minute if not set and never if negative.
+	CacheFor time.Duration
+	// Chats are the chats the bot answers in and posts the changes to.
+	// Messages from any other chat are ignored, as the assignments are
+	// those of a single account.
+	Chats []string
+	// BaseDomain is the platform the links of the assignments point to.
+	BaseDomain string
+	// Now returns the current time, time.Now if not set.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	cached    []assignment.Assignment
+	fetchedAt time.Time
+}
+
+const helpText = `Εντολές:
+/deadlines - οι επόμενες προθεσμίες
+/unsent - οι επόμενες προθεσμίες χωρίς υποβολή
+/course ΚΩΔΙΚΟΣ - οι επόμενες προθεσμίες ενός μαθήματος
+/help - αυτό το μήνυμα`
+
+// Run answers the messages until ctx is cancelled. Failures to receive or
+// to answer are logged and retried.
+func (b *Bot) Run(ctx context.Context) error {
+	for b.Name == "" {
+		name, err := b.Adapter.Name(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			log.Println("bot:", err.Error())
+			if !sleep(ctx, retryDelay) {
+				return nil
+			}
+			continue
+		case name == "":
+			return errors.New("bot: the adapter has no bot name")
+		}
+		b.Name = name
+	}
+
+	for {
+		messages, err := b.Adapter.Receive(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			log.Println("bot:", err.Error())
+			if !sleep(ctx, retryDelay) {
+				return nil
+			}
+			continue
+		}
+
+		for _, msg := range messages {
+			if !b.isChat(msg.Chat) {
+				continue
+			}
+
+			reply, ok := b.Answer(ctx, msg.Text)
+			if !ok {
+				continue
+			}
+			if err = b.Adapter.Send(ctx, msg.Chat, reply); err != nil {
+				log.Println("bot:", err.Error())
+			}
+		}
+	}
+}
+
+// Answer returns the reply to a message, and false for messages that are
+// not commands of the bot. Commands may carry the name of the bot, as
+// /deadlines@name.
+func (b *Bot) Answer(ctx context.Context, text string) (string, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "/") {
+		return "", false
+	}
+
+	command, name, addressed := strings.Cut(strings.TrimPrefix(fields[0], "/"), "@")
+	if addressed && !strings.EqualFold(name, b.Name) {
+		return "", false
+	}
+	arg := strings.Join(fields[1:], " ")
+
+	var keep func(a assignment.Assignment) bool
+	var title string
+	switch strings.ToLower(command) {
+	case "deadlines":
+		title = "Επόμενες προθεσμίες"
+		keep = func(assignment.Assignment) bool { return true }
+	case "unsent":
+		title = "Επόμενες προθεσμίες χωρίς υποβολή"
+		keep = func(a assignment.Assignment) bool { return !a.IsSent }
+	case "course":
+		if arg == "" {
+			return "Χρήση: /course ΚΩΔΙΚΟΣ, π.χ. /course CS152", true
+		}
+		title = "Επόμενες προθεσμίες του " + arg
+		keep = func(a assignment.Assignment) bool { return matchesCourse(a, arg) }
+	case "help", "start":
+		return helpText, true
+	default:
+		return "", false
+	}
+
+	assignments, err := b.fetch(ctx)
+	var partial *assignment.PartialError
+	switch {
+	case errors.As(err, &partial) && assignments != nil:
+	case errors.Is(err, login.ErrInvalidCredentials):
+		return "Αποτυχία σύνδεσης στο e-class: λάθος στοιχεία", true
+	case err != nil:
+		log.Println("bot:", err.Error())
+		return "Αποτυχία ανάκτησης των εργασιών, δοκιμάστε ξανά αργότερα", true
+	}
+
+	now := b.now()
+	upcoming := make([]assignment.Assignment, 0, len(assignments))
+	for _, a := range assignments {
+		if a.Deadline.After(now) && keep(a) {
+			upcoming = append(upcoming, a)
+		}
+	}
+
+	reply := b.format(title, upcoming, now)
+	if partial != nil {
+		names := make([]string, 0, len(partial.Errors))
+		for _, e := range partial.Errors {
+			names = append(names, e.Course.Name)
+		}
+		reply += "\n\nΔεν ανακτήθηκαν τα μαθήματα: " + strings.Join(names, ", ")
+	}
+	return reply, true
+}
+
+// fetch returns the assignments of the last fetch while they are fresh
+// enough, and fetches them again otherwise. Failed fetches are not kept.
+func (b *Bot) fetch(ctx context.Context) ([]assignment.Assignment, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cacheFor := b.CacheFor
+	if cacheFor == 0 {
+		cacheFor = defaultCacheFor
+	}
+	now := b.now()
+	if b.cached != nil && now.Sub(b.fetchedAt) < cacheFor {
+		return b.cached, nil
+	}
+
+	assignments, err := b.Fetch(ctx)
+	if err != nil {
+		return assignments, err
+	}
+	b.cached, b.fetchedAt = assignments, now
+	return assignments, nil
+}
+
+// Notify posts the changes to every chat of the bot, in one message. A
+// chat that fails does not stop the rest and the failures are returned
+// together.
+func (b *Bot) Notify(ctx context.Context, changes []watch.Change) error {
+	lines := make([]string, 0, len(changes))
+	for _, c := range changes {
+		line := c.String()
+		if assignmentURL, err := c.Assignment.FullURL(b.BaseDomain); err == nil && assignmentURL != "" {
+			line += "\n" + assignmentURL
+		}
+		lines = append(lines, line)
+	}
+	text := strings.Join(lines, "\n\n")
+
+	var failures []string
+	for _, chat := range b.Chats {
+		if err := b.Adapter.Send(ctx, chat, text); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failures = append(failures, fmt.Sprintf("chat %v: %v", chat, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf(
+			"bot: failed to notify %v of %v chat(s):\n%v",
+			len(failures),
+			len(b.Chats),
+			strings.Join(failures, "\n"),
+		)
+	}
+	return nil
+}
+
+// format lists the assignments under the title, one paragraph each.
+func (b *Bot) format(title string, assignments []assignment.Assignment, now time.Time) string {
+	if len(assignments) == 0 {
+		return title + ": καμία"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(title + ":")
+	for _, a := range assignments {
+		sb.WriteString("\n\n")
+		if a.IsExercise() {
+			sb.WriteString("◷ ")
+		}
+		fmt.Fprintf(&sb, "%v: %v\n", a.Course.Name, a.Title)
+		fmt.Fprintf(
+			&sb,
+			"Προθεσμία %v (%v)",
+			a.Deadline.In(assignment.Location).Format("02/01/2006 15:04"),
+			remaining(a.Deadline.Sub(now)),
+		)
+		if a.IsSent {
+			sb.WriteString(", υποβλήθηκε")
+		}
+		if assignmentURL, err := a.FullURL(b.BaseDomain); err == nil && assignmentURL != "" {
+			sb.WriteString("\n" + assignmentURL)
+		}
+	}
+	return sb.String()
+}
+
+// remaining is the time left until a deadline in days or hours.
+func remaining(d time.Duration) string {
+	switch days := int(d.Hours()) / 24; {
+	case days > 1:
+		return fmt.Sprintf("σε %v μέρες", days)
+	case days == 1:
+		return "σε 1 μέρα"
+	}
+	if hours := int(d.Hours()); hours > 1 {
+		return fmt.Sprintf("σε %v ώρες", hours)
+	}
+	return "σε λιγότερο από 2 ώρες"
+}
+
+// matchesCourse reports whether the course of the assignment has the given
+// code, or a name that contains it, ignoring case and accents.
+func matchesCourse(a assignment.Assignment, query string) bool {
+	if strings.EqualFold(a.Course.ID, query) {
+		return true
+	}
+	return strings.Contains(text.Normalize(a.Course.Name), text.Normalize(query))
+}
+
+func (b *Bot) isChat(chat string) bool {
+	for _, c := range b.Chats {
+		if c == chat {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Bot) now() time.Time {
+	if b.Now != nil {
+		return b.Now()
+	}
+	return time.Now()
+}
+
+// sleep waits for d, and reports false when ctx is cancelled first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,275 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/watch"
+)
+
+// fakeAdapter delivers the given messages once, then waits for ctx, and
+// keeps what is sent to the chats that do not fail.
+type fakeAdapter struct {
+	messages []Message
+	sent     []Message
+	failing  map[string]bool
+	received chan struct{}
+}
+
+func (f *fakeAdapter) Receive(ctx context.Context) ([]Message, error) {
+	if f.messages != nil {
+		messages := f.messages
+		f.messages = nil
+		return messages, nil
+	}
+	close(f.received)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (f *fakeAdapter) Name(context.Context) (string, error) {
+	return "eclass_bot", nil
+}
+
+func (f *fakeAdapter) Send(_ context.Context, chat, text string) error {
+	if f.failing[chat] {
+		return errors.New("chat not found")
+	}
+	f.sent = append(f.sent, Message{Chat: chat, Text: text})
+	return nil
+}
+
+var now = time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC)
+
+func newBot(adapter Adapter) *Bot {
+	ice262 := &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"}
+	cs152 := &course.Course{ID: "CS152", Name: "Αλγόριθμοι"}
+
+	return &Bot{
+		Adapter: adapter,
+		Fetch: func(context.Context) ([]assignment.Assignment, error) {
+			return []assignment.Assignment{
+				{ID: "1", Course: ice262, Title: "Ληγμένη", Deadline: now.Add(-time.Hour)},
+				{ID: "2", Course: cs152, Title: "Εργασία 1", Deadline: now.Add(3 * time.Hour), IsSent: true},
+				{ID: "3", Course: ice262, Title: "Άσκηση 2", Deadline: now.AddDate(0, 0, 3)},
+			}, nil
+		},
+		Chats:      []string{"42"},
+		BaseDomain: "example.com",
+		Now:        func() time.Time { return now },
+	}
+}
+
+func TestBotAnswer(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+		missing  []string
+	}{
+		{
+			text:     "/deadlines",
+			expected: []string{"Εργασία 1", "υποβλήθηκε", "Άσκηση 2", "σε 3 μέρες"},
+			missing:  []string{"Ληγμένη"},
+		},
+		{
+			text:     "/unsent@eclass_bot",
+			expected: []string{"Άσκηση 2"},
+			missing:  []string{"Εργασία 1", "Ληγμένη"},
+		},
+		{
+			text:     "/course cs152",
+			expected: []string{"Εργασία 1"},
+			missing:  []string{"Άσκηση 2"},
+		},
+		{
+			text:     "/course ανακτηση",
+			expected: []string{"Άσκηση 2", "https://example.com/"},
+			missing:  []string{"Εργασία 1"},
+		},
+		{
+			text:     "/course EN101",
+			expected: []string{"καμία"},
+		},
+		{
+			text:     "/start",
+			expected: []string{"/deadlines", "/unsent", "/course"},
+		},
+		{
+			text:     "/help@Eclass_Bot",
+			expected: []string{"/deadlines", "/unsent", "/course"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			// Arrange
+			b := newBot(nil)
+			b.Name = "eclass_bot"
+
+			// Act
+			actual, ok := b.Answer(context.Background(), tt.text)
+
+			// Assert
+			if !ok {
+				t.Fatalf("Expected: %v, Actual: %v", true, ok)
+			}
+			for _, s := range tt.expected {
+				if !strings.Contains(actual, s) {
+					t.Errorf("Expected: %v, Actual: %v", s, actual)
+				}
+			}
+			for _, s := range tt.missing {
+				if strings.Contains(actual, s) {
+					t.Errorf("Expected: no %v, Actual: %v", s, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestBotAnswer_Ignored(t *testing.T) {
+	tests := []string{"καλημέρα", "/ban", "/deadlines@other_bot"}
+
+	for _, text := range tests {
+		// Arrange
+		b := newBot(nil)
+		b.Name = "eclass_bot"
+
+		// Act
+		actual, ok := b.Answer(context.Background(), text)
+
+		// Assert
+		if ok {
+			t.Errorf("%v: Expected: %v, Actual: %v", text, "no reply", actual)
+		}
+	}
+}
+
+func TestBotAnswer_Cache(t *testing.T) {
+	// Arrange
+	b := newBot(nil)
+	fetch := b.Fetch
+	fetches := 0
+	b.Fetch = func(ctx context.Context) ([]assignment.Assignment, error) {
+		fetches++
+		return fetch(ctx)
+	}
+	current := now
+	b.Now = func() time.Time { return current }
+
+	// Act
+	b.Answer(context.Background(), "/deadlines")
+	current = current.Add(30 * time.Second)
+	b.Answer(context.Background(), "/unsent")
+	current = current.Add(time.Minute)
+	b.Answer(context.Background(), "/deadlines")
+
+	// Assert
+	if fetches != 2 {
+		t.Errorf("Expected: %v, Actual: %v", 2, fetches)
+	}
+}
+
+func TestBotRun(t *testing.T) {
+	// Arrange
+	adapter := &fakeAdapter{
+		messages: []Message{
+			{Chat: "42", Text: "καλημέρα"},
+			{Chat: "7", Text: "/deadlines"},
+			{Chat: "42", Text: "/deadlines@other_bot"},
+			{Chat: "42", Text: "/unsent@eclass_bot"},
+		},
+		received: make(chan struct{}),
+	}
+	b := newBot(adapter)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	// Act
+	go func() { done <- b.Run(ctx) }()
+	<-adapter.received
+	cancel()
+	err := <-done
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(adapter.sent) != 1 {
+		t.Fatalf("Expected: %v, Actual: %v", 1, adapter.sent)
+	}
+	if adapter.sent[0].Chat != "42" || !strings.Contains(adapter.sent[0].Text, "Άσκηση 2") {
+		t.Errorf("Expected: %v, Actual: %v", "the unsent assignments in chat 42", adapter.sent[0])
+	}
+}
+
+func TestBotNotify(t *testing.T) {
+	// Arrange
+	adapter := &fakeAdapter{}
+	b := newBot(adapter)
+	b.Chats = []string{"42", "-100"}
+
+	a := assignment.Assignment{
+		ID:       "24692",
+		Course:   &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"},
+		Title:    "Άσκηση 1",
+		Deadline: now.AddDate(0, 0, 7),
+	}
+	changes := []watch.Change{
+		{Type: watch.ChangeNew, Assignment: a},
+		{Type: watch.ChangeReminder, Assignment: a, LeadTime: 7 * 24 * time.Hour},
+	}
+
+	// Act
+	err := b.Notify(context.Background(), changes)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(adapter.sent) != 2 {
+		t.Fatalf("Expected: %v, Actual: %v", 2, len(adapter.sent))
+	}
+	for i, chat := range b.Chats {
+		sent := adapter.sent[i]
+		if sent.Chat != chat ||
+			!strings.Contains(sent.Text, "Νέα εργασία") ||
+			!strings.Contains(sent.Text, "Υπενθύμιση") {
+			t.Errorf("Expected: %v, Actual: %v", "both changes in chat "+chat, sent)
+		}
+	}
+}
+
+func TestBotNotify_FailingChat(t *testing.T) {
+	// Arrange
+	adapter := &fakeAdapter{failing: map[string]bool{"42": true}}
+	b := newBot(adapter)
+	b.Chats = []string{"42", "-100"}
+
+	changes := []watch.Change{{
+		Type: watch.ChangeNew,
+		Assignment: assignment.Assignment{
+			ID:       "24692",
+			Course:   &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"},
+			Title:    "Άσκηση 1",
+			Deadline: now.AddDate(0, 0, 7),
+		},
+	}}
+
+	// Act
+	err := b.Notify(context.Background(), changes)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "chat 42") {
+		t.Errorf("Expected: %v, Actual: %v", "the failure of chat 42", err)
+	}
+	if len(adapter.sent) != 1 || adapter.sent[0].Chat != "-100" {
+		t.Errorf("Expected: %v, Actual: %v", "the changes in chat -100", adapter.sent)
+	}
+}
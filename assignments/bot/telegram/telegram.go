@@ -0,0 +1,211 @@
+// Package telegram is the bot.Adapter of the Telegram Bot API.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/Huray-hub/eclass-utils/assignments/bot"
+)
+
+// DefaultAPIURL is the server of the Bot API.
+const DefaultAPIURL = "https://api.telegram.org"
+
+// maxLength is the longest text of a message, in UTF-16 code units.
+const maxLength = 4096
+
+// Client receives the messages of the bot by long polling and sends its
+// replies.
+type Client struct {
+	Token string
+	// APIURL is the Bot API server, ex. a local one.
+	APIURL string
+	// PollTimeout is how long the server holds a poll without updates.
+	PollTimeout time.Duration
+	HTTP        *http.Client
+
+	// offset is the ID of the next update, so that the server drops the
+	// ones received.
+	offset int64
+}
+
+// New returns the client of the bot with the given token on the server at
+// apiURL, DefaultAPIURL if empty.
+func New(token, apiURL string) (*Client, error) {
+	if token == "" {
+		return nil, errors.New("telegram: no token")
+	}
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	pollTimeout := 50 * time.Second
+	return &Client{
+		Token:       token,
+		APIURL:      strings.TrimSuffix(apiURL, "/"),
+		PollTimeout: pollTimeout,
+		HTTP:        &http.Client{Timeout: pollTimeout + 10*time.Second},
+	}, nil
+}
+
+type response struct {
+	OK          bool            `json:"ok"`
+	Description string          `json:"description"`
+	Result      json.RawMessage `json:"result"`
+}
+
+type update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *message `json:"message"`
+}
+
+type message struct {
+	Chat struct {
+		ID int64 `json:"id"`
+	} `json:"chat"`
+	Text string `json:"text"`
+}
+
+// Receive waits for the next text messages sent to the bot.
+func (c *Client) Receive(ctx context.Context) ([]bot.Message, error) {
+	params := map[string]interface{}{
+		"offset":          c.offset,
+		"timeout":         int(c.PollTimeout.Seconds()),
+		"allowed_updates": []string{"message"},
+	}
+
+	var updates []update
+	if err := c.call(ctx, "getUpdates", params, &updates); err != nil {
+		return nil, err
+	}
+
+	messages := make([]bot.Message, 0, len(updates))
+	for _, u := range updates {
+		if u.UpdateID >= c.offset {
+			c.offset = u.UpdateID + 1
+		}
+		if u.Message == nil || u.Message.Text == "" {
+			continue
+		}
+		messages = append(messages, bot.Message{
+			Chat: strconv.FormatInt(u.Message.Chat.ID, 10),
+			Text: u.Message.Text,
+		})
+	}
+	return messages, nil
+}
+
+// Name returns the username of the bot.
+func (c *Client) Name(ctx context.Context) (string, error) {
+	var me struct {
+		Username string `json:"username"`
+	}
+	if err := c.call(ctx, "getMe", map[string]interface{}{}, &me); err != nil {
+		return "", err
+	}
+	return me.Username, nil
+}
+
+// Send posts the text to the chat, in several messages when longer than a
+// message allows.
+func (c *Client) Send(ctx context.Context, chat, text string) error {
+	for _, part := range split(text, maxLength) {
+		params := map[string]interface{}{
+			"chat_id":                  chat,
+			"text":                     part,
+			"disable_web_page_preview": true,
+		}
+		if err := c.call(ctx, "sendMessage", params, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// call runs a method of the Bot API and decodes its result into result,
+// unless nil.
+func (c *Client) call(ctx context.Context, method string, params, result interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	endpoint := c.APIURL + "/bot" + c.Token + "/" + method
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("telegram %v: %w", method, redact(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("telegram %v: %w", method, redact(err))
+	}
+	defer resp.Body.Close()
+
+	var r response
+	if err = json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return fmt.Errorf("telegram %v: status %v: %w", method, resp.Status, err)
+	}
+	if !r.OK {
+		return fmt.Errorf("telegram %v: %v", method, r.Description)
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err = json.Unmarshal(r.Result, result); err != nil {
+		return fmt.Errorf("telegram %v: %w", method, err)
+	}
+	return nil
+}
+
+// redact drops the URL from the errors of the HTTP client, as it contains
+// the token.
+func redact(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// split cuts text into parts of up to limit UTF-16 code units, at line
+// breaks where possible.
+func split(text string, limit int) []string {
+	parts := make([]string, 0, 1)
+	for length(text) > limit {
+		runes := []rune(text)
+		cut, size := 0, 0
+		for i, r := range runes {
+			size += len(utf16.Encode([]rune{r}))
+			if size > limit {
+				break
+			}
+			cut = i + 1
+		}
+
+		if nl := strings.LastIndex(string(runes[:cut]), "\n"); nl > 0 {
+			parts = append(parts, text[:nl])
+			text = text[nl+1:]
+			continue
+		}
+		part := string(runes[:cut])
+		parts = append(parts, part)
+		text = text[len(part):]
+	}
+	return append(parts, text)
+}
+
+func length(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
@@ -0,0 +1,167 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Huray-hub/eclass-utils/assignments/bot"
+)
+
+const token = "123:secret"
+
+// botAPI is a stand-in of the Bot API server that serves the given updates
+// once and keeps the messages sent.
+type botAPI struct {
+	mu      sync.Mutex
+	updates []string
+	offsets []float64
+	sent    []map[string]interface{}
+}
+
+func newBotAPI(t *testing.T, updates ...string) (*botAPI, *httptest.Server) {
+	api := &botAPI{updates: updates}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		switch r.URL.Path {
+		case "/bot" + token + "/getUpdates":
+			api.offsets = append(api.offsets, params["offset"].(float64))
+			fmt.Fprintf(w, `{"ok":true,"result":[%v]}`, strings.Join(api.updates, ","))
+			api.updates = nil
+		case "/bot" + token + "/getMe":
+			fmt.Fprint(w, `{"ok":true,"result":{"id":123,"is_bot":true,"username":"eclass_bot"}}`)
+		case "/bot" + token + "/sendMessage":
+			api.sent = append(api.sent, params)
+			fmt.Fprint(w, `{"ok":true,"result":{"message_id":1}}`)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"ok":false,"error_code":401,"description":"Unauthorized"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return api, server
+}
+
+func newClient(t *testing.T, server *httptest.Server) *Client {
+	c, err := New(token, server.URL)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return c
+}
+
+func TestClientReceive(t *testing.T) {
+	// Arrange
+	api, server := newBotAPI(t,
+		`{"update_id":10,"message":{"chat":{"id":-1001},"text":"/deadlines"}}`,
+		`{"update_id":11,"edited_message":{"chat":{"id":-1001},"text":"/unsent"}}`,
+		`{"update_id":12,"message":{"chat":{"id":42},"text":"/course CS152"}}`,
+	)
+	c := newClient(t, server)
+
+	// Act
+	first, err := c.Receive(context.Background())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	second, err := c.Receive(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []bot.Message{
+		{Chat: "-1001", Text: "/deadlines"},
+		{Chat: "42", Text: "/course CS152"},
+	}
+	if fmt.Sprint(first) != fmt.Sprint(expected) {
+		t.Errorf("Expected: %v, Actual: %v", expected, first)
+	}
+	if len(second) != 0 {
+		t.Errorf("Expected: %v, Actual: %v", 0, len(second))
+	}
+	if fmt.Sprint(api.offsets) != "[0 13]" {
+		t.Errorf("Expected: %v, Actual: %v", "[0 13]", api.offsets)
+	}
+}
+
+func TestClientSend(t *testing.T) {
+	// Arrange
+	api, server := newBotAPI(t)
+	c := newClient(t, server)
+
+	line := strings.Repeat("α", 99)
+	text := strings.TrimSuffix(strings.Repeat(line+"\n", 50), "\n")
+
+	// Act
+	err := c.Send(context.Background(), "-1001", text)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(api.sent) != 2 {
+		t.Fatalf("Expected: %v, Actual: %v", 2, len(api.sent))
+	}
+	var joined []string
+	for _, params := range api.sent {
+		if params["chat_id"] != "-1001" {
+			t.Errorf("Expected: %v, Actual: %v", "-1001", params["chat_id"])
+		}
+		part := params["text"].(string)
+		if length(part) > maxLength || strings.HasSuffix(part, "\n") {
+			t.Errorf("Expected: %v, Actual: %v", "a part cut at a line break", length(part))
+		}
+		joined = append(joined, part)
+	}
+	if strings.Join(joined, "\n") != text {
+		t.Errorf("Expected: %v, Actual: %v", "the parts to make up the text", joined)
+	}
+}
+
+func TestClientName(t *testing.T) {
+	// Arrange
+	_, server := newBotAPI(t)
+	c := newClient(t, server)
+
+	// Act
+	name, err := c.Name(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if name != "eclass_bot" {
+		t.Errorf("Expected: %v, Actual: %v", "eclass_bot", name)
+	}
+}
+
+func TestClient_InvalidToken(t *testing.T) {
+	// Arrange
+	_, server := newBotAPI(t)
+	c, err := New("456:wrong", server.URL)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Act
+	_, err = c.Receive(context.Background())
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "Unauthorized") || strings.Contains(err.Error(), "wrong") {
+		t.Errorf("Expected: %v, Actual: %v", "Unauthorized without the token", err)
+	}
+}
@@ -5,14 +5,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	as "github.com/Huray-hub/eclass-utils/assignments/assignment"
+	ev "github.com/Huray-hub/eclass-utils/assignments/event"
 	ics "github.com/arran4/golang-ical"
 )
 
-func Export(a []as.Assignment, baseDomain string) (string, error) {
-	buffer, err := createCalendar(a, baseDomain)
+// Export writes the deadlines of the assignments and the events of the
+// agendas into an ICS file in the working directory and returns its path.
+func Export(a []as.Assignment, events []ev.Event, baseDomain string) (string, error) {
+	buffer, err := createCalendar(a, events, baseDomain)
 	if err != nil {
 		return "", err
 	}
@@ -38,6 +42,7 @@ func Export(a []as.Assignment, baseDomain string) (string, error) {
 
 func createCalendar(
 	a []as.Assignment,
+	events []ev.Event,
 	baseDomain string,
 ) (*bytes.Buffer, error) {
 	cal := ics.NewCalendar()
@@ -53,6 +58,9 @@ func createCalendar(
 			return nil, err
 		}
 	}
+	for _, v := range events {
+		addAgendaEvent(v, cal)
+	}
 
 	b := bytes.NewBufferString("")
 	err := cal.SerializeTo(b)
@@ -64,7 +72,12 @@ func createCalendar(
 }
 
 func addEvent(a as.Assignment, cal *ics.Calendar, baseDomain string) error {
-	event := cal.AddEvent(fmt.Sprintf("%v-%v-%v", "eclass-utils", a.Course.ID, a.ID))
+	uid := fmt.Sprintf("%v-%v-%v", "eclass-utils", a.Course.ID, a.ID)
+	if a.IsExercise() {
+		// exercise IDs may repeat the ones of the assignments of the course
+		uid = fmt.Sprintf("%v-%v-%v-%v", "eclass-utils", a.Course.ID, a.Kind, a.ID)
+	}
+	event := cal.AddEvent(uid)
 	event.SetCreatedTime(time.Now())
 	event.SetDtStampTime(time.Now())
 	event.SetModifiedAt(time.Now())
@@ -72,17 +85,66 @@ func addEvent(a as.Assignment, cal *ics.Calendar, baseDomain string) error {
 	event.SetEndAt(a.Deadline)
 	event.SetSummary(fmt.Sprintf("%v: %v", a.Course.Name, a.Title))
 
-	assignmentURL, err := a.PrepareURL(baseDomain)
+	assignmentURL, err := a.FullURL(baseDomain)
 	if err != nil {
 		return err
 	}
-	description := "https://" + assignmentURL
+	description := assignmentURL
 
-	if a.IsSent {
+	if a.Manual {
+		description = description + "\n" + "Χειροκίνητη καταχώριση"
+	}
+	if a.IsExercise() {
+		description = description + "\n" + exerciseDescription(a)
+	} else if a.IsSent {
 		description = description + "\n" + "Έχει σταλεί"
 	}
-	event.SetDescription(description)
-	event.SetURL(assignmentURL)
+	event.SetDescription(strings.TrimPrefix(description, "\n"))
+	if assignmentURL != "" {
+		event.SetURL(assignmentURL)
+	}
 
 	return nil
 }
+
+func addAgendaEvent(e ev.Event, cal *ics.Calendar) {
+	event := cal.AddEvent(fmt.Sprintf("%v-%v-%v", "eclass-utils", "event", e.Key()))
+	event.SetCreatedTime(time.Now())
+	event.SetDtStampTime(time.Now())
+	event.SetModifiedAt(time.Now())
+	event.SetStartAt(e.Start)
+	if e.End.IsZero() {
+		event.SetEndAt(e.Start)
+	} else {
+		event.SetEndAt(e.End)
+	}
+
+	if e.Course != nil {
+		event.SetSummary(fmt.Sprintf("%v: %v", e.Course.Name, e.Title))
+	} else {
+		event.SetSummary(e.Title)
+	}
+
+	description := strings.TrimSpace(e.URL + "\n" + e.Content)
+	if description != "" {
+		event.SetDescription(description)
+	}
+	if e.URL != "" {
+		event.SetURL(e.URL)
+	}
+}
+
+// exerciseDescription describes the time window and the attempts of an
+// exercise.
+func exerciseDescription(a as.Assignment) string {
+	description := "Ηλεκτρονική άσκηση"
+	if !a.Start.IsZero() {
+		description += ", από " + a.Start.Format("02/01/2006 15:04")
+	}
+
+	allowed := "απεριόριστες"
+	if a.AttemptsAllowed > 0 {
+		allowed = fmt.Sprint(a.AttemptsAllowed)
+	}
+	return fmt.Sprintf("%v\nΠροσπάθειες: %v από %v", description, a.AttemptsUsed, allowed)
+}
@@ -59,7 +59,7 @@ func TestExport(t *testing.T) {
 	}
 
 	// Act
-	res, err := calendar.Export(assignments[:], baseDomain)
+	res, err := calendar.Export(assignments[:], nil, baseDomain)
 
 	// Assert
 	if err != nil {
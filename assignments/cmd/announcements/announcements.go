@@ -0,0 +1,82 @@
+package announcements
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/announcement"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/connect"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/output"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+)
+
+// Show prints the announcements of the courses that were not shown by an
+// earlier run, and marks them as read.
+//
+//	announcements [-course=CS152,ICE262] [-all] [-format=table|json|ndjson]
+func Show(args []string) error {
+	fs := flag.NewFlagSet("announcements", flag.ContinueOnError)
+	courseIDs := fs.String("course", "", "Only these courses, by ID (ex. -course=CS152,ICE262)")
+	all := fs.Bool("all", false, "Show the read announcements as well")
+	format := fs.String("format", "", "Output format: table, json or ndjson")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	statePath, err := announcement.StatePath()
+	if err != nil {
+		return err
+	}
+	state, err := announcement.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, opts, err := connect.Login(ctx, func(*config.Options) {})
+	if err != nil {
+		return err
+	}
+	if *format != "" {
+		opts.Format = *format
+	}
+
+	courses, err := connect.Courses(ctx, client, *courseIDs)
+	if err != nil {
+		return err
+	}
+
+	// read announcements are listed without their body, unless shown
+	skipRead := state
+	if *all {
+		skipRead = nil
+	}
+
+	announcements, err := client.Announcements(ctx, courses, skipRead)
+	if announcements == nil && err != nil {
+		return err
+	} else if err != nil {
+		log.Println(err.Error())
+		fmt.Fprintln(os.Stderr, err.Error())
+	}
+
+	shown := announcements
+	if !*all {
+		shown = state.Unread(announcements)
+	}
+
+	err = output.PrintAnnouncements(shown, opts)
+	if err != nil {
+		return err
+	}
+
+	state.MarkRead(shown, time.Now())
+	return state.Save(statePath)
+}
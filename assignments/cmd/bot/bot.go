@@ -0,0 +1,55 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/notify"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+)
+
+// Run answers the commands sent to the bot of the config file in its chats
+// until interrupted. The notifications of the watch command are posted to
+// the same chats by the watch command itself.
+//
+//	bot
+func Run(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	opts, creds := &cfg.Options, &cfg.Credentials
+
+	fs := flag.NewFlagSet("bot", flag.ContinueOnError)
+	if err = fs.Parse(args); err != nil {
+		return err
+	}
+
+	err = config.Ensure(opts, creds)
+	if err != nil {
+		return err
+	}
+
+	b, err := notify.NewBot(cfg.Bot, opts.BaseDomain)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return errors.New("no bot configured, see the bot section of the config file")
+	}
+	b.Fetch = func(ctx context.Context) ([]assignment.Assignment, error) {
+		return assignment.GetContext(ctx, opts, creds)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprintln(os.Stderr, "Answering in the chats of the bot, press Ctrl+C to stop")
+	return b.Run(ctx)
+}
@@ -0,0 +1,77 @@
+package connect
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/eclass"
+	"github.com/Huray-hub/eclass-utils/assignments/session"
+)
+
+// Login logs in with the options and the credentials of the config file,
+// asking for the missing ones. configure adjusts the options of the
+// command before the client is created.
+func Login(ctx context.Context, configure func(*config.Options)) (*eclass.Client, *config.Options, error) {
+	opts, creds, err := config.Import()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = config.Ensure(opts, creds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configure(opts)
+	client, err := NewClient(*opts, *creds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = client.Login(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, opts, nil
+}
+
+// NewClient creates a client that keeps its session in the default
+// session file. It has to log in before fetching anything.
+func NewClient(opts config.Options, creds config.Credentials) (*eclass.Client, error) {
+	sessionPath, err := session.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+
+	return eclass.NewClient(
+		opts.BaseDomain,
+		creds,
+		eclass.WithOptions(opts),
+		eclass.WithSessionFile(sessionPath),
+	), nil
+}
+
+// Courses fetches the enrolled courses, only the ones in the comma
+// separated list of IDs if given.
+func Courses(ctx context.Context, client *eclass.Client, ids string) ([]course.Course, error) {
+	courses, err := client.Courses(ctx)
+	if err != nil || ids == "" {
+		return courses, err
+	}
+
+	picked := make(map[string]struct{})
+	for _, id := range strings.Split(ids, ",") {
+		picked[strings.TrimSpace(id)] = struct{}{}
+	}
+
+	res := make([]course.Course, 0, len(picked))
+	for _, c := range courses {
+		if _, ok := picked[c.ID]; ok {
+			res = append(res, c)
+		}
+	}
+	return res, nil
+}
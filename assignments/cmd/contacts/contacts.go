@@ -0,0 +1,81 @@
+package contacts
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/connect"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/output"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/professor"
+)
+
+// Show prints the contact information of the professors of the courses
+// and exports it as vCard when asked to.
+//
+//	contacts [-course=CS152,ICE262] [-format=table|json|ndjson] [-vcf=contacts.vcf]
+func Show(args []string) error {
+	fs := flag.NewFlagSet("contacts", flag.ContinueOnError)
+	courseIDs := fs.String("course", "", "Only these courses, by ID (ex. -course=CS152,ICE262)")
+	format := fs.String("format", "", "Output format: table, json or ndjson")
+	vcf := fs.String("vcf", "", "Also export the contacts to this vCard file (ex. -vcf=contacts.vcf)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, opts, err := connect.Login(ctx, func(*config.Options) {})
+	if err != nil {
+		return err
+	}
+	if *format != "" {
+		opts.Format = *format
+	}
+
+	courses, err := connect.Courses(ctx, client, *courseIDs)
+	if err != nil {
+		return err
+	}
+
+	professors, err := client.Professors(ctx, courses)
+	if professors == nil && err != nil {
+		return err
+	} else if err != nil {
+		log.Println(err.Error())
+		fmt.Fprintln(os.Stderr, err.Error())
+	}
+
+	err = output.PrintContacts(professors, opts)
+	if err != nil {
+		return err
+	}
+
+	if *vcf == "" {
+		return nil
+	}
+	return exportVCards(*vcf, professors)
+}
+
+func exportVCards(path string, professors []professor.Professor) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err = professor.WriteVCards(file, professors); err != nil {
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "stored %v contact(s) in\n%v\n", len(professors), path)
+	return nil
+}
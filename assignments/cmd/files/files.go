@@ -0,0 +1,118 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/connect"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/download"
+	"github.com/Huray-hub/eclass-utils/assignments/eclass"
+)
+
+// Download mirrors the attachments and the submitted files of the
+// assignments into a local directory.
+//
+//	download [-dir=eclass] [-course=CS152,ICE262] [-id=24692] [-i]
+func Download(args []string) error {
+	fs := flag.NewFlagSet("download", flag.ContinueOnError)
+	dir := fs.String("dir", "eclass", "Directory to download the files into")
+	courseIDs := fs.String("course", "", "Only these courses, by ID (ex. -course=CS152,ICE262)")
+	assignmentIDs := fs.String("id", "", "Only these assignments, by ID (ex. -id=24692)")
+	includeExpired := fs.Bool("i", true, "Include expired assignments")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, _, err := connect.Login(ctx, func(opts *config.Options) {
+		opts.IncludeExpired = *includeExpired
+		opts.FetchDetails = true
+	})
+	if err != nil {
+		return err
+	}
+
+	filter := download.Filter{
+		CourseIDs:     parseSet(*courseIDs),
+		AssignmentIDs: parseSet(*assignmentIDs),
+	}
+
+	assignments, err := fetch(ctx, client, filter)
+	if err != nil {
+		return err
+	}
+
+	report, err := client.Download(ctx, *dir, assignments, filter)
+	if report != nil {
+		printReport(report, *dir)
+	}
+	return err
+}
+
+// fetch fetches the assignments of the courses picked by filter. Courses
+// that fail are logged and left out.
+func fetch(
+	ctx context.Context,
+	client *eclass.Client,
+	filter download.Filter,
+) ([]assignment.Assignment, error) {
+	courses, err := client.Courses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	picked := make([]course.Course, 0, len(courses))
+	for _, c := range courses {
+		if _, ok := filter.CourseIDs[c.ID]; ok || len(filter.CourseIDs) == 0 {
+			picked = append(picked, c)
+		}
+	}
+
+	assignments, err := client.CoursesAssignments(ctx, picked)
+	var partial *assignment.PartialError
+	if errors.As(err, &partial) && assignments != nil {
+		log.Println(err.Error())
+		fmt.Fprintln(os.Stderr, err.Error())
+		return assignments, nil
+	}
+	return assignments, err
+}
+
+func parseSet(raw string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			set[item] = struct{}{}
+		}
+	}
+	return set
+}
+
+// printReport lists the new and the changed files of report, marked with
+// + and ~ respectively, and sums them up.
+func printReport(report *download.Report, dir string) {
+	for _, path := range report.New {
+		fmt.Println("+ " + path)
+	}
+	for _, path := range report.Changed {
+		fmt.Println("~ " + path)
+	}
+	fmt.Printf(
+		"%v new, %v changed and %v unchanged file(s) in %v\n",
+		len(report.New),
+		len(report.Changed),
+		len(report.Skipped),
+		dir,
+	)
+}
@@ -0,0 +1,142 @@
+package files
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/connect"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/eclass"
+)
+
+// Submit uploads files to an assignment, after asking for confirmation.
+//
+//	submit -course=ICE262 -id=24692 [-dry-run] [-y] file...
+func Submit(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	courseID := fs.String("course", "", "Course ID (ex. -course=ICE262)")
+	id := fs.String("id", "", "Assignment ID (ex. -id=24692)")
+	dryRun := fs.Bool("dry-run", false, "Show what would be submitted without uploading")
+	yes := fs.Bool("y", false, "Do not ask for confirmation")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	paths := fs.Args()
+	switch {
+	case *courseID == "":
+		return errors.New("missing course ID, use -course")
+	case *id == "":
+		return errors.New("missing assignment ID, use -id")
+	case len(paths) == 0:
+		return errors.New("missing files to submit")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// the assignment is picked by its IDs, so find looks for it even when
+	// the options exclude it
+	client, _, err := connect.Login(ctx, func(opts *config.Options) {
+		opts.IncludeExpired = true
+		opts.FetchDetails = false
+		opts.ExcludedCourses = nil
+		opts.ExcludedAssignments = nil
+	})
+	if err != nil {
+		return err
+	}
+
+	a, err := find(ctx, client, *courseID, *id)
+	if err != nil {
+		return err
+	}
+
+	form, err := client.SubmissionForm(ctx, a)
+	if err != nil {
+		return err
+	}
+	if err = form.Check(paths); err != nil {
+		return err
+	}
+
+	printSubmission(a, paths)
+	if *dryRun {
+		fmt.Println("dry run, nothing was submitted")
+		return nil
+	}
+	if !*yes && !confirm("Submit? [y/N] ") {
+		fmt.Println("cancelled")
+		return nil
+	}
+
+	err = client.Submit(ctx, a, form, paths)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("submitted")
+	return nil
+}
+
+// find fetches the assignment with the given course and assignment ID.
+func find(
+	ctx context.Context,
+	client *eclass.Client,
+	courseID string,
+	id string,
+) (assignment.Assignment, error) {
+	courses, err := client.Courses(ctx)
+	if err != nil {
+		return assignment.Assignment{}, err
+	}
+
+	for _, crs := range courses {
+		if crs.ID != courseID {
+			continue
+		}
+
+		assignments, err := client.Assignments(ctx, crs)
+		if err != nil {
+			return assignment.Assignment{}, err
+		}
+		for _, a := range assignments {
+			if a.ID == id && !a.IsExercise() {
+				return a, nil
+			}
+		}
+		return assignment.Assignment{}, fmt.Errorf("assignment %v not found in course %v", id, courseID)
+	}
+
+	return assignment.Assignment{}, fmt.Errorf("course %v not found", courseID)
+}
+
+func printSubmission(a assignment.Assignment, paths []string) {
+	fmt.Printf("%v (%v)\n", a.Course.Name, a.Course.ID)
+	fmt.Printf("%v, deadline %v\n", a.Title, a.Deadline.Format("02/01/2006 15:04"))
+	if a.IsSent {
+		fmt.Println("already submitted, the new files may replace the submitted ones")
+	}
+	for _, path := range paths {
+		fmt.Println("  " + path)
+	}
+}
+
+func confirm(question string) bool {
+	fmt.Print(question)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
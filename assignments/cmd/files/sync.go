@@ -0,0 +1,143 @@
+package files
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/connect"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/documents"
+	"github.com/Huray-hub/eclass-utils/assignments/download"
+)
+
+// Sync mirrors the documents of the courses into a local directory, as
+// <course>/<folders>/<document>, and reports the new and changed ones.
+//
+//	sync [-dir=documents] [-course=CS152,ICE262]
+func Sync(args []string) error {
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	dir := fs.String("dir", "documents", "Directory to mirror the documents into")
+	courseIDs := fs.String("course", "", "Only these courses, by ID (ex. -course=CS152,ICE262)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, opts, err := connect.Login(ctx, func(*config.Options) {})
+	if err != nil {
+		return err
+	}
+
+	courses, err := connect.Courses(ctx, client, *courseIDs)
+	if err != nil {
+		return err
+	}
+
+	files := make([]download.File, 0, 100)
+	var failures []string
+	for _, crs := range courses {
+		root, err := client.Documents(ctx, crs)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("course %v: %v", crs.ID, err))
+			continue
+		}
+
+		for _, doc := range root.AllFiles() {
+			if !opts.IsDocumentIncluded(crs.ID, doc.Path) {
+				continue
+			}
+
+			files = append(files, download.File{
+				Path:    download.Join(append([]string{crs.ID}, strings.Split(doc.Path, "/")...)...),
+				URL:     doc.URL,
+				Version: doc.Version(),
+			})
+		}
+	}
+
+	report, err := client.DownloadFiles(ctx, *dir, files)
+	if report != nil {
+		printReport(report, *dir)
+	}
+	if err != nil {
+		return err
+	}
+	return coursesError(failures)
+}
+
+// List prints the document trees of the courses.
+//
+//	documents [-course=CS152,ICE262]
+func List(args []string) error {
+	fs := flag.NewFlagSet("documents", flag.ContinueOnError)
+	courseIDs := fs.String("course", "", "Only these courses, by ID (ex. -course=CS152,ICE262)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, _, err := connect.Login(ctx, func(*config.Options) {})
+	if err != nil {
+		return err
+	}
+
+	courses, err := connect.Courses(ctx, client, *courseIDs)
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, crs := range courses {
+		root, err := client.Documents(ctx, crs)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("course %v: %v", crs.ID, err))
+			continue
+		}
+
+		fmt.Printf("%v (%v)\n", crs.Name, crs.ID)
+		printFolder(root, 1)
+	}
+	return coursesError(failures)
+}
+
+// coursesError lists the courses whose documents failed, or is nil.
+func coursesError(failures []string) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf(
+		"failed to fetch documents of %v course(s):\n%v",
+		len(failures),
+		strings.Join(failures, "\n"),
+	)
+}
+
+func printFolder(folder *documents.Folder, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	for i := range folder.Folders {
+		fmt.Printf("%v%v/\n", indent, folder.Folders[i].Name)
+		printFolder(&folder.Folders[i], depth+1)
+	}
+	for _, file := range folder.Files {
+		var date string
+		if !file.Date.IsZero() {
+			date = file.Date.Format("02/01/2006")
+		}
+		fmt.Printf("%v%v  %v  %v\n", indent, file.Name, file.Size, date)
+	}
+}
@@ -12,7 +12,26 @@ func Read(opts *config.Options, creds *config.Credentials) {
 		&opts.PlainText,
 		"p",
 		opts.PlainText,
-		"Print results in plain csv format",
+		"Print results in csv format (same as -format=csv)",
+	)
+	flag.StringVar(
+		&opts.Format,
+		"format",
+		opts.Format,
+		"Output format: table, csv, json or ndjson",
+	)
+	flag.StringVar(
+		&opts.Template,
+		"template",
+		opts.Template,
+		`Print every assignment through a named template of the config file
+or the given Go template (ex. -template='{{ .Title }} {{ remaining .Deadline }}')`,
+	)
+	flag.BoolVar(
+		&opts.Interactive,
+		"t",
+		opts.Interactive,
+		"Browse assignments in an interactive terminal UI",
 	)
 	flag.BoolVar(
 		&opts.IncludeExpired,
@@ -21,11 +40,40 @@ func Read(opts *config.Options, creds *config.Credentials) {
 		"Include expired assignments",
 	)
 	flag.BoolVar(&opts.ExportICS, "c", opts.ExportICS, "Export calendar file")
+	flag.BoolVar(
+		&opts.FetchDetails,
+		"details",
+		opts.FetchDetails,
+		"Also fetch the description, attachments and submission of every assignment",
+	)
+	flag.BoolVar(
+		&opts.Offline,
+		"offline",
+		opts.Offline,
+		"Show the assignments of the last successful fetch without connecting",
+	)
+	flag.IntVar(
+		&opts.Parallelism,
+		"j",
+		opts.Parallelism,
+		"Number of courses fetched at the same time",
+	)
 	baseDomain := flag.String(
 		"d",
 		"",
 		"Specify base e-class domain (ex. -d=eclass.uniwa.gr)",
 	)
+	csvColumns := flag.String(
+		"columns",
+		"",
+		"Columns of the csv format (ex. -columns=courseName,title,deadline)",
+	)
+	flag.StringVar(
+		&opts.CSVDelimiter,
+		"delimiter",
+		opts.CSVDelimiter,
+		`Delimiter of the csv format, a single character or \t`,
+	)
 	excludedCourses := flag.String(
 		"e",
 		"",
@@ -43,7 +91,7 @@ Use course ID and a part of the assignment's title to ignore it from results
 
 	flag.Parse()
 
-	flagsToOptions(*baseDomain, *excludedCourses, *excludedAssignments, opts)
+	flagsToOptions(*baseDomain, *excludedCourses, *excludedAssignments, *csvColumns, opts)
 	flagsToCredentials(*username, *password, creds)
 }
 
@@ -51,6 +99,7 @@ func flagsToOptions(
 	baseDomain string,
 	excludedCourses string,
 	excludedAssignments string,
+	csvColumns string,
 	opts *config.Options,
 ) {
 	if baseDomain != "" {
@@ -64,6 +113,10 @@ func flagsToOptions(
 	if excludedAssignments != "" {
 		opts.ExcludedAssignments = parseExcludedAssignments(excludedAssignments)
 	}
+
+	if csvColumns != "" {
+		opts.CSVColumns = parseList(csvColumns)
+	}
 }
 
 func parseExcludedCourses(raw string) map[string]struct{} {
@@ -77,6 +130,16 @@ func parseExcludedCourses(raw string) map[string]struct{} {
 	return res
 }
 
+func parseList(raw string) []string {
+	values := strings.Split(raw, ",")
+
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		res = append(res, strings.TrimSpace(v))
+	}
+	return res
+}
+
 func parseExcludedAssignments(raw string) map[string][]string {
 	kvPairs := strings.Split(raw, "_")
 	res := make(map[string][]string, len(kvPairs))
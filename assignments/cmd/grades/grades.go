@@ -0,0 +1,99 @@
+package grades
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/connect"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/output"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/eclass"
+)
+
+// Show prints the graded assignments of every course, along with the
+// course gradebooks when asked to.
+//
+//	grades [-course=CS152,ICE262] [-gradebook] [-format=table|json|ndjson]
+func Show(args []string) error {
+	fs := flag.NewFlagSet("grades", flag.ContinueOnError)
+	courseIDs := fs.String("course", "", "Only these courses, by ID (ex. -course=CS152,ICE262)")
+	withGradebook := fs.Bool("gradebook", false, "Also fetch the gradebook of every course")
+	format := fs.String("format", "", "Output format: table, json or ndjson")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, opts, err := connect.Login(ctx, func(opts *config.Options) {
+		opts.IncludeExpired = true
+		opts.FetchDetails = true
+	})
+	if err != nil {
+		return err
+	}
+	if *format != "" {
+		opts.Format = *format
+	}
+
+	courses, err := connect.Courses(ctx, client, *courseIDs)
+	if err != nil {
+		return err
+	}
+
+	assignments, err := client.CoursesAssignments(ctx, courses)
+	var partial *assignment.PartialError
+	if errors.As(err, &partial) && assignments != nil {
+		log.Println(err.Error())
+		fmt.Fprintln(os.Stderr, err.Error())
+	} else if err != nil {
+		return err
+	}
+
+	grades := assignment.Grades(assignments)
+	if *withGradebook {
+		grades, err = addGradebooks(ctx, client, courses, grades)
+		if err != nil {
+			return err
+		}
+	}
+
+	return output.PrintGrades(grades, opts)
+}
+
+// addGradebooks fetches the gradebooks of the courses into grades, adding
+// the courses that have a gradebook but no graded assignments.
+func addGradebooks(
+	ctx context.Context,
+	client *eclass.Client,
+	courses []course.Course,
+	grades []assignment.CourseGrades,
+) ([]assignment.CourseGrades, error) {
+	index := make(map[string]int, len(grades))
+	for i, g := range grades {
+		index[g.Course.ID] = i
+	}
+
+	for _, c := range courses {
+		gradebook, err := client.Gradebook(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("gradebook of course %v: %w", c.ID, err)
+		}
+
+		if i, ok := index[c.ID]; ok {
+			grades[i].Gradebook = gradebook
+		} else if len(gradebook.Activities) > 0 {
+			grades = append(grades, assignment.CourseGrades{Course: c, Gradebook: gradebook})
+		}
+	}
+
+	return grades, nil
+}
@@ -1,33 +1,47 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"time"
 
 	"github.com/Huray-hub/eclass-utils/assignments/assignment"
 	"github.com/Huray-hub/eclass-utils/assignments/calendar"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/announcements"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/bot"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/connect"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/contacts"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/files"
 	"github.com/Huray-hub/eclass-utils/assignments/cmd/flags"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/grades"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/manual"
 	"github.com/Huray-hub/eclass-utils/assignments/cmd/output"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/tui"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/watch"
 	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/eclass"
+	"github.com/Huray-hub/eclass-utils/assignments/event"
+	"github.com/Huray-hub/eclass-utils/assignments/login"
+	"github.com/Huray-hub/eclass-utils/assignments/snapshot"
 )
 
+// exitInvalidCredentials is the exit code when e-class rejects the login,
+// to tell it apart from network and other failures (exit code 1).
+const exitInvalidCredentials = 2
+
 func init() {
-	homeCache, err := os.UserCacheDir()
+	path, err := config.CacheDir()
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 
-	path := filepath.Join(homeCache, "eclass-utils")
-	if _, err = os.Stat(path); errors.Is(err, os.ErrNotExist) {
-		err = os.MkdirAll(path, 0755)
-		if err != nil {
-			log.Fatal(err)
-		}
-	}
-
 	file, err := os.OpenFile(
 		filepath.Join(path, "assignments.log"),
 		os.O_APPEND|os.O_CREATE|os.O_WRONLY,
@@ -45,7 +59,39 @@ func init() {
 	log.SetOutput(file)
 }
 
+// commands are run instead of printing the assignments when their name is
+// the first argument.
+var commands = map[string]func(args []string) error{
+	"add":           manual.Add,
+	"edit":          manual.Edit,
+	"remove":        manual.Remove,
+	"download":      files.Download,
+	"submit":        files.Submit,
+	"grades":        grades.Show,
+	"announcements": announcements.Show,
+	"documents":     files.List,
+	"sync":          files.Sync,
+	"contacts":      contacts.Show,
+	"watch":         watch.Run,
+	"bot":           bot.Run,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := commands[os.Args[1]]; ok {
+			err := run(os.Args[2:])
+			if err != nil && !errors.Is(err, flag.ErrHelp) {
+				log.Println(err.Error())
+				fmt.Fprintln(os.Stderr, err.Error())
+				if errors.Is(err, login.ErrInvalidCredentials) {
+					os.Exit(exitInvalidCredentials)
+				}
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	opts, creds, err := config.Import()
 	if err != nil {
 		log.Fatal(err.Error())
@@ -53,23 +99,68 @@ func main() {
 
 	flags.Read(opts, creds)
 
-	err = config.Ensure(opts, creds)
+	if !opts.Offline {
+		err = config.Ensure(opts, creds)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+
+	if opts.Interactive {
+		err = tui.Run(opts, creds)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, err := connect.NewClient(snapshot.Unfiltered(*opts), *creds)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 
-	assignments, err := assignment.Get(opts, creds)
+	snap, err := snapshot.Fetch(ctx, opts, client)
+	var partial *assignment.PartialError
+	if errors.Is(err, login.ErrInvalidCredentials) {
+		log.Println(err.Error())
+		fmt.Fprintln(os.Stderr, "Login failed, check your username and password:", err.Error())
+		os.Exit(exitInvalidCredentials)
+	} else if errors.As(err, &partial) && snap != nil {
+		log.Println(err.Error())
+		fmt.Fprintln(os.Stderr, err.Error())
+	} else if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	if snap.Offline {
+		printStaleness(snap)
+	}
+	assignments, err := snap.Filter(opts)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 
-	err = output.PrintAssignments(assignments, opts.PlainText)
+	err = output.PrintAssignments(assignments, opts)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 
 	if opts.ExportICS {
-		path, err := calendar.Export(assignments, opts.BaseDomain)
+		// the agenda is not kept in the snapshot, so offline calendars
+		// only have the deadlines
+		var events []event.Event
+		if !snap.Offline {
+			events, err = fetchEvents(ctx, opts, client, snap.Courses)
+			if err != nil {
+				log.Println(err.Error())
+				fmt.Fprintln(os.Stderr, "Fetching agenda events failed:", err.Error())
+			}
+		}
+
+		path, err := calendar.Export(assignments, events, opts.BaseDomain)
 		if err != nil {
 			log.Fatal(err.Error())
 		}
@@ -77,3 +168,42 @@ func main() {
 		fmt.Printf("stored in\n%v\n", path)
 	}
 }
+
+// fetchEvents fetches the agenda events of the courses that are not
+// excluded for the calendar file, from now on or from any time with
+// expired assignments. client is the one that fetched the assignments, so
+// it is logged in already.
+func fetchEvents(
+	ctx context.Context,
+	opts *config.Options,
+	client *eclass.Client,
+	courses []course.Course,
+) ([]event.Event, error) {
+	included := make([]course.Course, 0, len(courses))
+	for _, c := range courses {
+		if !opts.IsCourseExcluded(c.ID) {
+			included = append(included, c)
+		}
+	}
+
+	from := time.Now()
+	if opts.IncludeExpired {
+		from = time.Unix(0, 0)
+	}
+
+	return client.Events(ctx, included, from, time.Now().AddDate(1, 0, 0))
+}
+
+func printStaleness(snap *snapshot.Snapshot) {
+	if snap.FetchErr != nil {
+		log.Println(snap.FetchErr.Error())
+		fmt.Fprintln(os.Stderr, "Fetching failed:", snap.FetchErr.Error())
+	}
+
+	fmt.Fprintf(
+		os.Stderr,
+		"Offline: showing assignments fetched at %v (%v ago)\n",
+		snap.FetchedAt.Format("02/01/2006 15:04"),
+		snap.Age().Round(time.Minute),
+	)
+}
@@ -0,0 +1,198 @@
+package manual
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+)
+
+type entryFlags struct {
+	courseID  *string
+	title     *string
+	deadline  *string
+	url       *string
+	submitted *bool
+}
+
+func newFlagSet(name string) (*flag.FlagSet, entryFlags) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	return fs, entryFlags{
+		courseID: fs.String("course", "", "Course ID (ex. -course=CS152)"),
+		title:    fs.String("title", "", "Title of the assignment"),
+		deadline: fs.String(
+			"deadline",
+			"",
+			"Deadline in Greek local time (ex. -deadline=\"2022-12-21 23:59\")",
+		),
+		url:       fs.String("url", "", "Link to the assignment, if any"),
+		submitted: fs.Bool("submitted", false, "Mark the assignment as submitted"),
+	}
+}
+
+// Add appends a manual assignment to the config file.
+//
+//	add -course=CS152 -title="Εργασία" -deadline="2022-12-21 23:59" [-url=...] [-submitted]
+func Add(args []string) error {
+	fs, flags := newFlagSet("add")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	entry := config.ManualAssignment{
+		CourseID:  strings.TrimSpace(*flags.courseID),
+		Title:     strings.TrimSpace(*flags.title),
+		Deadline:  strings.TrimSpace(*flags.deadline),
+		URL:       strings.TrimSpace(*flags.url),
+		Submitted: *flags.submitted,
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	entry.ID = nextID(cfg.Options.ManualAssignments)
+	if err = validate(entry); err != nil {
+		return err
+	}
+
+	cfg.Options.ManualAssignments = append(cfg.Options.ManualAssignments, entry)
+	if err = config.Save(cfg); err != nil {
+		return err
+	}
+
+	fmt.Printf("added manual assignment %v\n", entry.ID)
+	return nil
+}
+
+// Edit changes the given fields of a manual assignment.
+//
+//	edit -id=m1 [-course=...] [-title=...] [-deadline=...] [-url=...] [-submitted=true|false]
+func Edit(args []string) error {
+	fs, flags := newFlagSet("edit")
+	id := fs.String("id", "", "ID of the manual assignment (ex. -id=m1)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	i, err := find(cfg.Options.ManualAssignments, *id)
+	if err != nil {
+		return err
+	}
+
+	entry := &cfg.Options.ManualAssignments[i]
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "course":
+			entry.CourseID = strings.TrimSpace(*flags.courseID)
+		case "title":
+			entry.Title = strings.TrimSpace(*flags.title)
+		case "deadline":
+			entry.Deadline = strings.TrimSpace(*flags.deadline)
+		case "url":
+			entry.URL = strings.TrimSpace(*flags.url)
+		case "submitted":
+			entry.Submitted = *flags.submitted
+		}
+	})
+
+	if err = validate(*entry); err != nil {
+		return err
+	}
+
+	if err = config.Save(cfg); err != nil {
+		return err
+	}
+
+	fmt.Printf("edited manual assignment %v\n", entry.ID)
+	return nil
+}
+
+// Remove deletes a manual assignment from the config file.
+//
+//	remove -id=m1
+func Remove(args []string) error {
+	fs := flag.NewFlagSet("remove", flag.ContinueOnError)
+	id := fs.String("id", "", "ID of the manual assignment (ex. -id=m1)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	i, err := find(cfg.Options.ManualAssignments, *id)
+	if err != nil {
+		return err
+	}
+
+	entries := cfg.Options.ManualAssignments
+	cfg.Options.ManualAssignments = append(entries[:i:i], entries[i+1:]...)
+
+	if err = config.Save(cfg); err != nil {
+		return err
+	}
+
+	fmt.Printf("removed manual assignment %v\n", *id)
+	return nil
+}
+
+func validate(entry config.ManualAssignment) error {
+	switch {
+	case entry.CourseID == "":
+		return errors.New("missing course ID, use -course")
+	case entry.Title == "":
+		return errors.New("missing title, use -title")
+	case entry.Deadline == "":
+		return errors.New("missing deadline, use -deadline")
+	}
+
+	_, err := assignment.ParseManualDeadline(entry.Deadline)
+	if err != nil {
+		return fmt.Errorf(
+			"invalid deadline %q, expected format %q",
+			entry.Deadline,
+			config.ManualDeadlineLayout,
+		)
+	}
+
+	return nil
+}
+
+func find(entries []config.ManualAssignment, id string) (int, error) {
+	if id == "" {
+		return 0, errors.New("missing manual assignment ID, use -id")
+	}
+
+	for i, entry := range entries {
+		if entry.ID == id {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("manual assignment %v not found", id)
+}
+
+// nextID returns an ID of the form m<N> that is not taken by any entry.
+func nextID(entries []config.ManualAssignment) string {
+	max := 0
+	for _, entry := range entries {
+		n, err := strconv.Atoi(strings.TrimPrefix(entry.ID, "m"))
+		if err == nil && n > max {
+			max = n
+		}
+	}
+	return "m" + strconv.Itoa(max+1)
+}
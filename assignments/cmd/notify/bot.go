@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"errors"
+
+	"github.com/Huray-hub/eclass-utils/assignments/bot"
+	"github.com/Huray-hub/eclass-utils/assignments/bot/telegram"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+)
+
+// NewBot returns the bot of cfg without a Fetch, which only the bot command
+// needs, or nil when no chat protocol is configured. The links of the
+// assignments point to the platform at baseDomain.
+func NewBot(cfg config.Bot, baseDomain string) (*bot.Bot, error) {
+	if cfg.Telegram.Token == "" {
+		return nil, nil
+	}
+	if len(cfg.Telegram.Chats) == 0 {
+		return nil, errors.New("telegram: no chats")
+	}
+
+	client, err := telegram.New(cfg.Telegram.Token, cfg.Telegram.APIURL)
+	if err != nil {
+		return nil, err
+	}
+	return &bot.Bot{
+		Adapter:    client,
+		Chats:      cfg.Telegram.Chats,
+		BaseDomain: baseDomain,
+	}, nil
+}
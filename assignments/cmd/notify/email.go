@@ -0,0 +1,345 @@
+// Package notify sends the notifications of the watch command, as
+// configured in the notifications section of the config file.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/output"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/watch"
+)
+
+// mailData is what both bodies of a mail are rendered from: the table of
+// the assignments as printed by the table format.
+type mailData struct {
+	Title  string
+	Header []string
+	Rows   [][]string
+}
+
+var textBody = template.Must(template.New("text").Parse(
+	`{{ .Title }}
+{{ range .Rows }}
+{{ range $i, $cell := . }}{{ index $.Header $i }}: {{ $cell }}
+{{ end }}{{ end }}`))
+
+var htmlBody = htmltemplate.Must(htmltemplate.New("html").Parse(
+	`<!DOCTYPE html>
+<html><body>
+<h3>{{ .Title }}</h3>
+<table border="1" cellpadding="4" cellspacing="0">
+<tr>{{ range .Header }}<th>{{ . }}</th>{{ end }}</tr>
+{{ range .Rows }}<tr>{{ range . }}<td>{{ . }}</td>{{ end }}</tr>
+{{ end }}</table>
+</body></html>
+`))
+
+// Email mails the new assignments as soon as they are found and a daily
+// digest of the upcoming deadlines.
+type Email struct {
+	Config config.Email
+	// StatePath keeps the time of the last digest, see DigestStatePath.
+	StatePath string
+	// Now returns the current time, time.Now if not set.
+	Now func() time.Time
+	// Timeout bounds the delivery of a mail when the context has no
+	// deadline, so that a stalled server does not block the watcher.
+	Timeout time.Duration
+	// TLSConfig is used for STARTTLS, a config for Config.Host if not set.
+	TLSConfig *tls.Config
+
+	digestAt   time.Duration
+	lastDigest *time.Time
+}
+
+// digestState is the file at Email.StatePath.
+type digestState struct {
+	LastDigest time.Time `json:"lastDigest"`
+}
+
+// DigestStatePath is the file in the cache directory that keeps the time
+// of the last digest.
+func DigestStatePath() (string, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "digest.json"), nil
+}
+
+// NewEmail checks the mail settings of cfg.
+func NewEmail(cfg config.Email, statePath string) (*Email, error) {
+	switch {
+	case cfg.Host == "":
+		return nil, errors.New("email: no host")
+	case cfg.From == "":
+		return nil, errors.New("email: no sender")
+	case len(cfg.To) == 0:
+		return nil, errors.New("email: no recipients")
+	}
+
+	e := &Email{Config: cfg, StatePath: statePath, Timeout: 30 * time.Second}
+	if cfg.DigestTime != "" {
+		t, err := time.Parse("15:04", cfg.DigestTime)
+		if err != nil {
+			return nil, fmt.Errorf("email: invalid digest time %q: %w", cfg.DigestTime, err)
+		}
+		e.digestAt = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	return e, nil
+}
+
+// Notify mails the new assignments among the changes, when immediate mails
+// are on.
+func (e *Email) Notify(ctx context.Context, changes []watch.Change) error {
+	if !e.Config.Immediate {
+		return nil
+	}
+
+	posted := make([]assignment.Assignment, 0)
+	for _, c := range changes {
+		if c.Type == watch.ChangeNew {
+			posted = append(posted, c.Assignment)
+		}
+	}
+	if len(posted) == 0 {
+		return nil
+	}
+
+	subject := fmt.Sprintf("Νέες εργασίες (%v)", len(posted))
+	return e.send(ctx, subject, posted)
+}
+
+// Observe mails the digest of the upcoming deadlines, once a day after the
+// digest time.
+func (e *Email) Observe(ctx context.Context, assignments []assignment.Assignment) error {
+	if e.Config.DigestTime == "" {
+		return nil
+	}
+
+	now := e.now().In(assignment.Location)
+	year, month, day := now.Date()
+	digestAt := time.Date(year, month, day, 0, 0, 0, 0, assignment.Location).Add(e.digestAt)
+
+	last, err := e.loadLastDigest()
+	if err != nil {
+		return err
+	}
+	if now.Before(digestAt) || !last.Before(digestAt) {
+		return nil
+	}
+
+	upcoming := make([]assignment.Assignment, 0, len(assignments))
+	for _, a := range assignments {
+		if a.Deadline.After(now) {
+			upcoming = append(upcoming, a)
+		}
+	}
+
+	if len(upcoming) > 0 {
+		subject := fmt.Sprintf("Προθεσμίες της %v", now.Format("02/01/2006"))
+		if err = e.send(ctx, subject, upcoming); err != nil {
+			return err
+		}
+	}
+
+	return e.saveLastDigest(now)
+}
+
+func (e *Email) now() time.Time {
+	if e.Now != nil {
+		return e.Now()
+	}
+	return time.Now()
+}
+
+func (e *Email) loadLastDigest() (time.Time, error) {
+	if e.lastDigest != nil {
+		return *e.lastDigest, nil
+	}
+
+	var state digestState
+	data, err := os.ReadFile(e.StatePath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return time.Time{}, err
+	default:
+		if err = json.Unmarshal(data, &state); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	e.lastDigest = &state.LastDigest
+	return state.LastDigest, nil
+}
+
+func (e *Email) saveLastDigest(t time.Time) error {
+	e.lastDigest = &t
+
+	data, err := json.Marshal(digestState{LastDigest: t})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(e.StatePath, data, 0600)
+}
+
+// send mails the assignments with the subject to every recipient.
+func (e *Email) send(ctx context.Context, subject string, assignments []assignment.Assignment) error {
+	header, rows := output.Table(assignments)
+	msg, err := e.message(subject, mailData{Title: subject, Header: header, Rows: rows})
+	if err != nil {
+		return err
+	}
+
+	if _, ok := ctx.Deadline(); !ok && e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	addr := net.JoinHostPort(e.Config.Host, strconv.Itoa(e.Config.Port))
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("email: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, e.Config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("email: %w", err)
+	}
+	defer client.Close()
+
+	if err = e.deliver(client, msg); err != nil {
+		return fmt.Errorf("email: %w", err)
+	}
+	return nil
+}
+
+func (e *Email) deliver(client *smtp.Client, msg []byte) error {
+	if e.Config.StartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("the server does not support STARTTLS")
+		}
+		tlsConfig := e.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{ServerName: e.Config.Host}
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if e.Config.Username != "" {
+		auth := smtp.PlainAuth("", e.Config.Username, e.Config.Password, e.Config.Host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(e.Config.From); err != nil {
+		return err
+	}
+	for _, to := range e.Config.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// message builds a multipart/alternative mail with a plain text and an HTML
+// body of the same data.
+func (e *Email) message(subject string, data mailData) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	var text bytes.Buffer
+	if err := textBody.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	var html bytes.Buffer
+	if err := htmlBody.Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=UTF-8", text.Bytes()},
+		{"text/html; charset=UTF-8", html.Bytes()},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err = qp.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err = qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	headers := []string{
+		"From: " + e.Config.From,
+		"To: " + strings.Join(e.Config.To, ", "),
+		"Subject: " + mime.QEncoding.Encode("UTF-8", subject),
+		"Date: " + e.now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + parts.Boundary(),
+	}
+	for _, h := range headers {
+		msg.WriteString(h + "\r\n")
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,449 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/watch"
+)
+
+// smtpSink is a local SMTP server that keeps the mails it receives. With a
+// TLS config it offers STARTTLS, and with a password it requires AUTH PLAIN.
+type smtpSink struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	password  string
+	mails     chan received
+}
+
+// received is a mail of the sink along with how it was delivered.
+type received struct {
+	data     []byte
+	tls      bool
+	username string
+}
+
+func newSMTPSink(t *testing.T) *smtpSink {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	sink := &smtpSink{listener: listener, mails: make(chan received, 10)}
+	go sink.serve()
+	return sink
+}
+
+func (s *smtpSink) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpSink) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpSink) handle(conn net.Conn) {
+	defer func() { conn.Close() }()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	var delivered received
+	reply("220 sink ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		switch cmd := strings.TrimSpace(line); {
+		case hasCommand(cmd, "EHLO"), hasCommand(cmd, "HELO"):
+			reply("250-sink")
+			if s.tlsConfig != nil && !delivered.tls {
+				reply("250-STARTTLS")
+			}
+			if s.password != "" {
+				reply("250-AUTH PLAIN")
+			}
+			reply("250 8BITMIME")
+		case hasCommand(cmd, "STARTTLS"):
+			reply("220 ready")
+			conn = tls.Server(conn, s.tlsConfig)
+			r = bufio.NewReader(conn)
+			delivered.tls = true
+		case hasCommand(cmd, "AUTH PLAIN"):
+			// the initial response is \x00username\x00password
+			creds, _ := base64.StdEncoding.DecodeString(strings.TrimSpace(cmd[len("AUTH PLAIN"):]))
+			parts := strings.Split(string(creds), "\x00")
+			if len(parts) != 3 || parts[2] != s.password {
+				reply("535 authentication failed")
+				continue
+			}
+			delivered.username = parts[1]
+			reply("235 authenticated")
+		case hasCommand(cmd, "MAIL"):
+			if s.password != "" && delivered.username == "" {
+				reply("530 authentication required")
+				continue
+			}
+			reply("250 ok")
+		case hasCommand(cmd, "DATA"):
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err = r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			delivered.data = []byte(data.String())
+			s.mails <- delivered
+			reply("250 queued")
+		case hasCommand(cmd, "QUIT"):
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func hasCommand(line, cmd string) bool {
+	return strings.HasPrefix(strings.ToUpper(line), cmd)
+}
+
+func (s *smtpSink) receive(t *testing.T) received {
+	select {
+	case delivered := <-s.mails:
+		return delivered
+	case <-time.After(5 * time.Second):
+		t.Fatal("no mail received")
+		return received{}
+	}
+}
+
+func (s *smtpSink) next(t *testing.T) *mail.Message {
+	msg, err := mail.ReadMessage(strings.NewReader(string(s.receive(t).data)))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return msg
+}
+
+// bodies reads the parts of a multipart/alternative mail by content type.
+func bodies(t *testing.T, msg *mail.Message) map[string]string {
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Expected: %v, Actual: %v (%v)", "multipart/alternative", mediaType, err)
+	}
+
+	res := make(map[string]string)
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			return res
+		}
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		// the reader decodes quoted-printable parts
+		content, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		res[contentType] = string(content)
+	}
+}
+
+func newEmail(t *testing.T, sink *smtpSink, now time.Time) *Email {
+	e, err := NewEmail(config.Email{
+		Host:       "127.0.0.1",
+		Port:       sink.port(),
+		From:       "eclass@example.com",
+		To:         []string{"me@example.com"},
+		Immediate:  true,
+		DigestTime: "08:00",
+	}, filepath.Join(t.TempDir(), "digest.json"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	e.Now = func() time.Time { return now }
+	return e
+}
+
+func TestEmailNotify(t *testing.T) {
+	// Arrange
+	sink := newSMTPSink(t)
+	now := time.Now()
+	e := newEmail(t, sink, now)
+
+	posted := assignment.Assignment{
+		ID:       "24692",
+		Course:   &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"},
+		Title:    "Άσκηση 1 <BM25>",
+		Deadline: now.AddDate(0, 0, 7),
+	}
+	changes := []watch.Change{
+		{Type: watch.ChangeNew, Assignment: posted},
+		{Type: watch.ChangeTitle, Assignment: posted, Previous: &posted},
+	}
+
+	// Act
+	err := e.Notify(context.Background(), changes)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	msg := sink.next(t)
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "Νέες εργασίες (1)" {
+		t.Errorf("Expected: %v, Actual: %v", "Νέες εργασίες (1)", subject)
+	}
+
+	parts := bodies(t, msg)
+	text := parts["text/plain"]
+	if !strings.Contains(text, "ΜΑΘΗΜΑ: ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ") ||
+		!strings.Contains(text, "ΕΡΓΑΣΙΑ: Άσκηση 1 <BM25>") {
+		t.Errorf("Expected: %v, Actual: %v", "the assignment in the plain text", text)
+	}
+	html := parts["text/html"]
+	if !strings.Contains(html, "<td>Άσκηση 1 &lt;BM25&gt;</td>") {
+		t.Errorf("Expected: %v, Actual: %v", "the escaped assignment in the HTML", html)
+	}
+}
+
+func TestEmailObserve(t *testing.T) {
+	// Arrange
+	sink := newSMTPSink(t)
+	morning := time.Date(2022, 12, 1, 7, 0, 0, 0, assignment.Location)
+
+	assignments := []assignment.Assignment{
+		{
+			ID:       "1",
+			Course:   &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"},
+			Title:    "Άσκηση 1",
+			Deadline: morning.AddDate(0, 0, 3),
+		},
+		{
+			ID:       "2",
+			Course:   &course.Course{ID: "CS152", Name: "Αλγόριθμοι"},
+			Title:    "Ληγμένη",
+			Deadline: morning.Add(-time.Hour),
+		},
+	}
+
+	steps := []struct {
+		name     string
+		now      time.Time
+		expected bool
+	}{
+		{"before the digest time", morning, false},
+		{"after the digest time", morning.Add(2 * time.Hour), true},
+		{"again the same day", morning.Add(5 * time.Hour), false},
+		{"the next day", morning.Add(25 * time.Hour), true},
+	}
+
+	statePath := filepath.Join(t.TempDir(), "digest.json")
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			// a new notifier every time, as after a restart
+			e := newEmail(t, sink, step.now)
+			e.StatePath = statePath
+
+			// Act
+			err := e.Observe(context.Background(), assignments)
+
+			// Assert
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if !step.expected {
+				select {
+				case <-sink.mails:
+					t.Errorf("Expected: %v, Actual: %v", "no digest", "a digest")
+				case <-time.After(100 * time.Millisecond):
+				}
+				return
+			}
+
+			text := bodies(t, sink.next(t))["text/plain"]
+			if !strings.Contains(text, "Άσκηση 1") || strings.Contains(text, "Ληγμένη") {
+				t.Errorf("Expected: %v, Actual: %v", "only the upcoming deadline", text)
+			}
+		})
+	}
+}
+
+func TestNewEmail_InvalidDigestTime(t *testing.T) {
+	// Act
+	_, err := NewEmail(config.Email{
+		Host:       "smtp.example.com",
+		Port:       587,
+		From:       "eclass@example.com",
+		To:         []string{"me@example.com"},
+		DigestTime: "8am",
+	}, "digest.json")
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected: %v, Actual: %v", "an error", err)
+	}
+}
+
+func TestEmailNotify_StartTLSAndAuth(t *testing.T) {
+	// Arrange
+	// the certificate of the test server is valid for 127.0.0.1
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	sink := newSMTPSink(t)
+	sink.tlsConfig = server.TLS
+	sink.password = "app-password"
+
+	tests := []struct {
+		name     string
+		startTLS bool
+		password string
+		fails    bool
+	}{
+		{name: "STARTTLS and login", startTLS: true, password: "app-password"},
+		{name: "login over plain text to localhost", password: "app-password"},
+		{name: "wrong password", startTLS: true, password: "wrong", fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEmail(t, sink, time.Now())
+			e.Config.StartTLS = tt.startTLS
+			e.Config.Username = "me@example.com"
+			e.Config.Password = tt.password
+			e.TLSConfig = &tls.Config{
+				ServerName: "127.0.0.1",
+				RootCAs:    server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs,
+			}
+
+			// Act
+			err := e.Notify(context.Background(), []watch.Change{{
+				Type: watch.ChangeNew,
+				Assignment: assignment.Assignment{
+					ID:       "1",
+					Course:   &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"},
+					Title:    "Άσκηση 1",
+					Deadline: time.Now().AddDate(0, 0, 7),
+				},
+			}})
+
+			// Assert
+			if (err != nil) != tt.fails {
+				t.Fatalf("Expected failure: %v, Actual: %v", tt.fails, err)
+			}
+			if tt.fails {
+				return
+			}
+			delivered := sink.receive(t)
+			if delivered.tls != tt.startTLS || delivered.username != "me@example.com" {
+				t.Errorf("Expected: %v, Actual: %v", "a mail through the expected path", delivered)
+			}
+		})
+	}
+}
+
+func TestEmailNotify_StartTLSNotSupported(t *testing.T) {
+	// Arrange
+	sink := newSMTPSink(t)
+	e := newEmail(t, sink, time.Now())
+	e.Config.StartTLS = true
+
+	// Act
+	err := e.Notify(context.Background(), []watch.Change{{
+		Type:       watch.ChangeNew,
+		Assignment: assignment.Assignment{ID: "1", Course: &course.Course{ID: "ICE262"}},
+	}})
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Errorf("Expected: %v, Actual: %v", "no mail without STARTTLS", err)
+	}
+}
+
+func TestEmailNotify_StalledServer(t *testing.T) {
+	// Arrange
+	// a server that accepts connections but never greets
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	e, err := NewEmail(config.Email{
+		Host:      "127.0.0.1",
+		Port:      listener.Addr().(*net.TCPAddr).Port,
+		From:      "eclass@example.com",
+		To:        []string{"me@example.com"},
+		Immediate: true,
+	}, "digest.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	e.Timeout = 100 * time.Millisecond
+
+	done := make(chan error)
+
+	// Act
+	go func() {
+		done <- e.Notify(context.Background(), []watch.Change{{
+			Type:       watch.ChangeNew,
+			Assignment: assignment.Assignment{ID: "1", Course: &course.Course{ID: "ICE262"}},
+		}})
+	}()
+
+	// Assert
+	select {
+	case err = <-done:
+		if err == nil {
+			t.Errorf("Expected: %v, Actual: %v", "a timeout", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected: %v, Actual: %v", "a timeout", "blocked")
+	}
+}
@@ -0,0 +1,282 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/output"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/watch"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the body, as sha256=<hex>,
+// when the webhook has a secret.
+const SignatureHeader = "X-Eclass-Signature"
+
+// Payload is the body of the json format. Fields are only ever added to
+// it, never renamed or removed.
+type Payload struct {
+	// SentAt is in RFC 3339 format
+	SentAt  string         `json:"sentAt"`
+	Changes []ChangeRecord `json:"changes"`
+}
+
+// ChangeRecord is a change of a Payload.
+type ChangeRecord struct {
+	// Type is one of the watch.ChangeType values
+	Type       string         `json:"type"`
+	Assignment output.Record  `json:"assignment"`
+	Previous   *output.Record `json:"previous,omitempty"`
+	// LeadTime is only present for reminders, as a Go duration (ex. 3h0m0s)
+	LeadTime string `json:"leadTime,omitempty"`
+}
+
+// chatMessage is the body of the chat format, with the text under the
+// field of Slack and the one of Discord.
+type chatMessage struct {
+	Text    string `json:"text"`
+	Content string `json:"content"`
+}
+
+// Webhook posts the changes to a URL, signed, and retries failed posts
+// with exponential backoff.
+type Webhook struct {
+	Config     config.Webhook
+	BaseDomain string
+	Client     *http.Client
+	// Backoff is the wait before the first retry, doubled on every retry.
+	Backoff time.Duration
+}
+
+// NewWebhook checks the webhook settings of cfg. The links of the
+// assignments point to the platform at baseDomain.
+func NewWebhook(cfg config.Webhook, baseDomain string) (*Webhook, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("webhook: invalid URL %q", cfg.URL)
+	}
+
+	switch cfg.Format {
+	case "":
+		cfg.Format = "json"
+	case "json", "chat":
+	default:
+		return nil, fmt.Errorf("webhook %v: unknown format %q", u.Host, cfg.Format)
+	}
+
+	if cfg.Retries == 0 {
+		cfg.Retries = 3
+	}
+
+	return &Webhook{
+		Config:     cfg,
+		BaseDomain: baseDomain,
+		Client:     &http.Client{Timeout: 30 * time.Second},
+		Backoff:    time.Second,
+	}, nil
+}
+
+// Notify posts the changes of the courses that are not excluded, all in one
+// body in digest mode or one by one otherwise. One by one, a failed post
+// does not stop the rest and the failures are returned together.
+func (w *Webhook) Notify(ctx context.Context, changes []watch.Change) error {
+	picked := make([]watch.Change, 0, len(changes))
+	for _, c := range changes {
+		if !w.Config.IsCourseExcluded(c.Assignment.Course.ID) {
+			picked = append(picked, c)
+		}
+	}
+	if len(picked) == 0 {
+		return nil
+	}
+
+	if w.Config.Digest {
+		return w.post(ctx, picked)
+	}
+
+	var failures []string
+	for _, c := range picked {
+		if err := w.post(ctx, []watch.Change{c}); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf(
+			"failed to post %v of %v change(s):\n%v",
+			len(failures),
+			len(picked),
+			strings.Join(failures, "\n"),
+		)
+	}
+	return nil
+}
+
+func (w *Webhook) post(ctx context.Context, changes []watch.Change) error {
+	body, err := w.body(changes)
+	if err != nil {
+		return err
+	}
+
+	backoff := w.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, wait, err := w.send(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= w.Config.Retries {
+			return fmt.Errorf("webhook %v: %w", w.host(), err)
+		}
+		if wait == 0 {
+			wait = backoff
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// send posts the body once, and reports whether a failure is worth a
+// retry: network failures, rate limits and server errors are. A rate limit
+// also reports the wait its Retry-After header asks for, if any.
+func (w *Webhook) send(ctx context.Context, body []byte) (bool, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.Config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Config.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(w.Config.Secret, body))
+	}
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode < 300:
+		return false, 0, nil
+	case resp.StatusCode == http.StatusTooManyRequests:
+		wait := retryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if wait > maxRetryAfter {
+			return false, 0, fmt.Errorf("status %v, retry after %v", resp.Status, wait)
+		}
+		return true, wait, fmt.Errorf("status %v", resp.Status)
+	case resp.StatusCode >= 500:
+		return true, 0, fmt.Errorf("status %v", resp.Status)
+	default:
+		return false, 0, fmt.Errorf("status %v", resp.Status)
+	}
+}
+
+// maxRetryAfter is the longest Retry-After a post waits for. Changes that
+// would wait longer are left for the next poll instead.
+const maxRetryAfter = 5 * time.Minute
+
+// retryAfter parses the value of a Retry-After header, either seconds or
+// an HTTP date, into a wait from now. It is 0 when missing or invalid.
+func retryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+func (w *Webhook) body(changes []watch.Change) ([]byte, error) {
+	if w.Config.Format == "chat" {
+		lines := make([]string, 0, len(changes))
+		for _, c := range changes {
+			line := c.String()
+			if assignmentURL, err := c.Assignment.FullURL(w.BaseDomain); err == nil && assignmentURL != "" {
+				line += " " + assignmentURL
+			}
+			lines = append(lines, line)
+		}
+		text := strings.Join(lines, "\n")
+		return json.Marshal(chatMessage{Text: text, Content: text})
+	}
+
+	payload := Payload{
+		SentAt:  time.Now().Format(time.RFC3339),
+		Changes: make([]ChangeRecord, 0, len(changes)),
+	}
+	for _, c := range changes {
+		record, err := w.newChangeRecord(c)
+		if err != nil {
+			return nil, err
+		}
+		payload.Changes = append(payload.Changes, record)
+	}
+	return json.Marshal(payload)
+}
+
+func (w *Webhook) newChangeRecord(c watch.Change) (ChangeRecord, error) {
+	record := ChangeRecord{Type: string(c.Type)}
+
+	var err error
+	record.Assignment, err = output.NewRecord(c.Assignment, w.BaseDomain)
+	if err != nil {
+		return ChangeRecord{}, err
+	}
+
+	if c.Previous != nil {
+		previous, err := output.NewRecord(*c.Previous, w.BaseDomain)
+		if err != nil {
+			return ChangeRecord{}, err
+		}
+		record.Previous = &previous
+	}
+	if c.LeadTime > 0 {
+		record.LeadTime = c.LeadTime.String()
+	}
+	return record, nil
+}
+
+func (w *Webhook) host() string {
+	u, err := url.Parse(w.Config.URL)
+	if err != nil {
+		return w.Config.URL
+	}
+	return u.Host
+}
+
+// Sign is the value of the SignatureHeader of a body signed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,293 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/watch"
+)
+
+// hookSink is a webhook endpoint that keeps the requests it receives and
+// answers with the given statuses in turn, then with 204. Rate limits
+// carry its retryAfter header, if set.
+type hookSink struct {
+	mu         sync.Mutex
+	statuses   []int
+	retryAfter string
+	requests   []*http.Request
+	bodies     [][]byte
+}
+
+func newHookSink(t *testing.T, statuses ...int) (*hookSink, *httptest.Server) {
+	sink := &hookSink{statuses: statuses}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+		sink.requests = append(sink.requests, r)
+		sink.bodies = append(sink.bodies, body)
+
+		status := http.StatusNoContent
+		if len(sink.statuses) > 0 {
+			status, sink.statuses = sink.statuses[0], sink.statuses[1:]
+		}
+		if status == http.StatusTooManyRequests && sink.retryAfter != "" {
+			w.Header().Set("Retry-After", sink.retryAfter)
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return sink, server
+}
+
+func newWebhook(t *testing.T, cfg config.Webhook) *Webhook {
+	w, err := NewWebhook(cfg, "example.com")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	w.Backoff = time.Millisecond
+	return w
+}
+
+func webhookChanges() []watch.Change {
+	deadline := time.Date(2022, 12, 8, 23, 59, 0, 0, assignment.Location)
+	return []watch.Change{
+		{
+			Type: watch.ChangeNew,
+			Assignment: assignment.Assignment{
+				ID:       "24692",
+				Course:   &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"},
+				Title:    "Άσκηση 1",
+				Deadline: deadline,
+			},
+		},
+		{
+			Type: watch.ChangeReminder,
+			Assignment: assignment.Assignment{
+				ID:       "31337",
+				Course:   &course.Course{ID: "CS152", Name: "Αλγόριθμοι"},
+				Title:    "Εργασία 2",
+				Deadline: deadline,
+			},
+			LeadTime: 3 * time.Hour,
+		},
+	}
+}
+
+func TestWebhookNotify(t *testing.T) {
+	// Arrange
+	sink, server := newHookSink(t)
+	w := newWebhook(t, config.Webhook{URL: server.URL, Secret: "s3cret"})
+
+	// Act
+	err := w.Notify(context.Background(), webhookChanges())
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(sink.bodies) != 2 {
+		t.Fatalf("Expected: %v, Actual: %v", 2, len(sink.bodies))
+	}
+
+	for i, body := range sink.bodies {
+		expected := Sign("s3cret", body)
+		if actual := sink.requests[i].Header.Get(SignatureHeader); actual != expected {
+			t.Errorf("Expected: %v, Actual: %v", expected, actual)
+		}
+	}
+
+	var payload Payload
+	if err = json.Unmarshal(sink.bodies[1], &payload); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(payload.Changes) != 1 {
+		t.Fatalf("Expected: %v, Actual: %v", 1, len(payload.Changes))
+	}
+	actual := payload.Changes[0]
+	if actual.Type != "reminder" || actual.LeadTime != "3h0m0s" || actual.Assignment.CourseID != "CS152" {
+		t.Errorf("Expected: %v, Actual: %+v", "the reminder of CS152", actual)
+	}
+}
+
+func TestWebhookNotify_OneFails(t *testing.T) {
+	// Arrange
+	sink, server := newHookSink(t, http.StatusBadRequest)
+	w := newWebhook(t, config.Webhook{URL: server.URL})
+
+	// Act
+	err := w.Notify(context.Background(), webhookChanges())
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "1 of 2") {
+		t.Errorf("Expected: %v, Actual: %v", "1 of 2 changes failed", err)
+	}
+	if len(sink.bodies) != 2 {
+		t.Errorf("Expected: %v, Actual: %v", 2, len(sink.bodies))
+	}
+}
+
+func TestWebhookNotify_Digest(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.Webhook
+		expected []string
+	}{
+		{
+			name:     "every change",
+			cfg:      config.Webhook{Format: "chat", Digest: true},
+			expected: []string{"Άσκηση 1", "Εργασία 2"},
+		},
+		{
+			name: "excluded course",
+			cfg: config.Webhook{
+				Format:          "chat",
+				Digest:          true,
+				ExcludedCourses: map[string]struct{}{"CS152": {}},
+			},
+			expected: []string{"Άσκηση 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			sink, server := newHookSink(t)
+			tt.cfg.URL = server.URL
+			w := newWebhook(t, tt.cfg)
+
+			// Act
+			err := w.Notify(context.Background(), webhookChanges())
+
+			// Assert
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if len(sink.bodies) != 1 {
+				t.Fatalf("Expected: %v, Actual: %v", 1, len(sink.bodies))
+			}
+
+			var msg chatMessage
+			if err = json.Unmarshal(sink.bodies[0], &msg); err != nil {
+				t.Fatal(err.Error())
+			}
+			lines := strings.Split(msg.Text, "\n")
+			if len(lines) != len(tt.expected) || msg.Content != msg.Text {
+				t.Fatalf("Expected: %v, Actual: %v", tt.expected, msg)
+			}
+			for i, title := range tt.expected {
+				if !strings.Contains(lines[i], title) || !strings.Contains(lines[i], "https://example.com/") {
+					t.Errorf("Expected: %v, Actual: %v", title, lines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookNotify_Retries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		attempts int
+		fails    bool
+	}{
+		{name: "server error", statuses: []int{503, 502}, attempts: 3},
+		{name: "rate limit", statuses: []int{429}, attempts: 2},
+		{name: "too many failures", statuses: []int{500, 500, 500, 500}, attempts: 4, fails: true},
+		{name: "client error", statuses: []int{400}, attempts: 1, fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			sink, server := newHookSink(t, tt.statuses...)
+			w := newWebhook(t, config.Webhook{URL: server.URL, Digest: true})
+
+			// Act
+			err := w.Notify(context.Background(), webhookChanges())
+
+			// Assert
+			if (err != nil) != tt.fails {
+				t.Fatalf("Expected failure: %v, Actual: %v", tt.fails, err)
+			}
+			if len(sink.bodies) != tt.attempts {
+				t.Errorf("Expected: %v, Actual: %v", tt.attempts, len(sink.bodies))
+			}
+		})
+	}
+}
+
+func TestWebhookNotify_RetryAfter(t *testing.T) {
+	// Arrange
+	sink, server := newHookSink(t, http.StatusTooManyRequests)
+	sink.retryAfter = "1"
+	w := newWebhook(t, config.Webhook{URL: server.URL, Digest: true})
+
+	// Act
+	start := time.Now()
+	err := w.Notify(context.Background(), webhookChanges())
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected: %v, Actual: %v", "a wait of 1s", elapsed)
+	}
+	if len(sink.bodies) != 2 {
+		t.Errorf("Expected: %v, Actual: %v", 2, len(sink.bodies))
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "", expected: 0},
+		{value: "120", expected: 2 * time.Minute},
+		{value: "-1", expected: 0},
+		{value: "Thu, 01 Dec 2022 12:00:30 GMT", expected: 30 * time.Second},
+		{value: "Thu, 01 Dec 2022 11:00:00 GMT", expected: 0},
+		{value: "soon", expected: 0},
+	}
+
+	for _, tt := range tests {
+		// Act
+		actual := retryAfter(tt.value, now)
+
+		// Assert
+		if actual != tt.expected {
+			t.Errorf("%q: Expected: %v, Actual: %v", tt.value, tt.expected, actual)
+		}
+	}
+}
+
+func TestNewWebhook_Invalid(t *testing.T) {
+	tests := []config.Webhook{
+		{URL: "example.com/hook"},
+		{URL: "https://example.com/hook", Format: "xml"},
+	}
+
+	for _, cfg := range tests {
+		// Act
+		_, err := NewWebhook(cfg, "example.com")
+
+		// Assert
+		if err == nil {
+			t.Errorf("Expected: %v, Actual: %v", "an error", err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/announcement"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+)
+
+// AnnouncementRecord is the schema of an announcement in the json and
+// ndjson formats.
+type AnnouncementRecord struct {
+	CourseID   string `json:"courseId"`
+	CourseName string `json:"courseName"`
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	// Date is in RFC 3339 format
+	Date        string             `json:"date,omitempty"`
+	Body        string             `json:"body"`
+	Attachments []AttachmentRecord `json:"attachments,omitempty"`
+	URL         string             `json:"url"`
+}
+
+func newAnnouncementRecord(a announcement.Announcement) AnnouncementRecord {
+	return AnnouncementRecord{
+		CourseID:    a.Course.ID,
+		CourseName:  a.Course.Name,
+		ID:          a.ID,
+		Title:       a.Title,
+		Date:        formatTime(a.Date),
+		Body:        a.Body,
+		Attachments: newAttachmentRecords(a.Attachments),
+		URL:         a.URL,
+	}
+}
+
+// PrintAnnouncements prints the announcements to Stdout, as text or in the
+// json and ndjson formats of opts.Format.
+func PrintAnnouncements(announcements []announcement.Announcement, opts *config.Options) error {
+	switch format(opts) {
+	case "table":
+		printAnnouncementsText(os.Stdout, announcements)
+		return nil
+	case "json", "ndjson":
+		return printAnnouncementsJSON(os.Stdout, announcements, format(opts) == "ndjson")
+	default:
+		return fmt.Errorf("announcements cannot be printed in the %v format", format(opts))
+	}
+}
+
+func printAnnouncementsText(w io.Writer, announcements []announcement.Announcement) {
+	if len(announcements) == 0 {
+		fmt.Fprintln(w, "Δεν υπάρχουν νέες ανακοινώσεις")
+		return
+	}
+
+	for i, a := range announcements {
+		if i > 0 {
+			fmt.Fprintln(w, strings.Repeat("─", 40))
+		}
+
+		date := "-"
+		if !a.Date.IsZero() {
+			date = a.Date.Format("02/01/2006")
+		}
+		fmt.Fprintf(w, "%v • %v\n%v\n", a.Course.Name, date, a.Title)
+		if a.Body != "" {
+			fmt.Fprintf(w, "\n%v\n", a.Body)
+		}
+		for _, attachment := range a.Attachments {
+			fmt.Fprintf(w, "📎 %v %v\n", attachment.Name, attachment.URL)
+		}
+		fmt.Fprintln(w, a.URL)
+	}
+}
+
+func printAnnouncementsJSON(
+	w io.Writer,
+	announcements []announcement.Announcement,
+	ndjson bool,
+) error {
+	records := make([]AnnouncementRecord, 0, len(announcements))
+	for _, a := range announcements {
+		records = append(records, newAnnouncementRecord(a))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	if !ndjson {
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	}
+
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/professor"
+	"github.com/olekukonko/tablewriter"
+)
+
+// ProfessorRecord is the schema of a professor in the json and ndjson
+// formats.
+type ProfessorRecord struct {
+	Name        string         `json:"name"`
+	Emails      []string       `json:"emails"`
+	Phones      []string       `json:"phones"`
+	OfficeHours []string       `json:"officeHours"`
+	Courses     []CourseRecord `json:"courses"`
+}
+
+// CourseRecord is a course of a ProfessorRecord.
+type CourseRecord struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+func newProfessorRecord(p professor.Professor) ProfessorRecord {
+	courses := make([]CourseRecord, 0, len(p.Courses))
+	for _, crs := range p.Courses {
+		courses = append(courses, CourseRecord(crs))
+	}
+
+	return ProfessorRecord{
+		Name:        p.Name,
+		Emails:      nonNil(p.Emails),
+		Phones:      nonNil(p.Phones),
+		OfficeHours: nonNil(p.OfficeHours),
+		Courses:     courses,
+	}
+}
+
+// nonNil makes empty lists print as [] instead of null.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// PrintContacts prints the professors to Stdout, as a table or in the json
+// and ndjson formats of opts.Format.
+func PrintContacts(professors []professor.Professor, opts *config.Options) error {
+	switch format(opts) {
+	case "table":
+		printContactsPretty(os.Stdout, professors)
+		return nil
+	case "json", "ndjson":
+		return printContactsJSON(os.Stdout, professors, format(opts) == "ndjson")
+	default:
+		return fmt.Errorf("contacts cannot be printed in the %v format", format(opts))
+	}
+}
+
+func printContactsPretty(w io.Writer, professors []professor.Professor) {
+	table := tablewriter.NewWriter(w)
+	table.SetRowLine(true)
+	table.SetHeader([]string{"ΟΝΟΜΑ", "EMAIL", "ΤΗΛΕΦΩΝΟ", "ΩΡΕΣ ΓΡΑΦΕΙΟΥ", "ΜΑΘΗΜΑΤΑ"})
+
+	for _, p := range professors {
+		courses := make([]string, 0, len(p.Courses))
+		for _, crs := range p.Courses {
+			courses = append(courses, crs.Name)
+		}
+
+		table.Append([]string{
+			p.Name,
+			strings.Join(p.Emails, "\n"),
+			strings.Join(p.Phones, "\n"),
+			strings.Join(p.OfficeHours, "\n"),
+			strings.Join(courses, "\n"),
+		})
+	}
+	table.Render()
+}
+
+func printContactsJSON(w io.Writer, professors []professor.Professor, ndjson bool) error {
+	records := make([]ProfessorRecord, 0, len(professors))
+	for _, p := range professors {
+		records = append(records, newProfessorRecord(p))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	if !ndjson {
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	}
+
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+)
+
+// DefaultCSVColumns are the columns printed when none are configured.
+var DefaultCSVColumns = []string{
+	"courseId",
+	"courseName",
+	"id",
+	"title",
+	"deadline",
+	"submitted",
+}
+
+// csvColumns maps the column names, the same as the json fields, to their
+// values.
+var csvColumns = map[string]func(Record) string{
+	"courseId":   func(r Record) string { return r.CourseID },
+	"courseName": func(r Record) string { return r.CourseName },
+	"courseUrl":  func(r Record) string { return r.CourseURL },
+	"id":         func(r Record) string { return r.ID },
+	"kind":       func(r Record) string { return r.Kind },
+	"title":      func(r Record) string { return r.Title },
+	"deadline":   func(r Record) string { return r.Deadline },
+	"submitted":  func(r Record) string { return strconv.FormatBool(r.Submitted) },
+	"url":        func(r Record) string { return r.URL },
+	"manual":     func(r Record) string { return strconv.FormatBool(r.Manual) },
+	"grade":      func(r Record) string { return r.Grade },
+	"start":      func(r Record) string { return r.Start },
+}
+
+// printAssignmentsCSV prints the assignments as RFC 4180 csv, with a
+// header row and the columns and delimiter of opts.
+func printAssignmentsCSV(
+	w io.Writer,
+	assignments []assignment.Assignment,
+	opts *config.Options,
+) error {
+	columns := opts.CSVColumns
+	if len(columns) == 0 {
+		columns = DefaultCSVColumns
+	}
+
+	values := make([]func(Record) string, 0, len(columns))
+	for _, column := range columns {
+		value, ok := csvColumns[column]
+		if !ok {
+			return fmt.Errorf("unknown csv column: %v", column)
+		}
+		values = append(values, value)
+	}
+
+	delimiter, err := csvDelimiter(opts.CSVDelimiter)
+	if err != nil {
+		return err
+	}
+
+	records, err := newRecords(assignments, opts.BaseDomain)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = delimiter
+
+	if err = writer.Write(columns); err != nil {
+		return err
+	}
+
+	row := make([]string, len(values))
+	for _, record := range records {
+		for i, value := range values {
+			row[i] = value(record)
+		}
+		if err = writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvDelimiter(raw string) (rune, error) {
+	switch raw {
+	case "":
+		return ',', nil
+	case `\t`, "tab":
+		return '\t', nil
+	}
+
+	r, size := utf8.DecodeRuneInString(raw)
+	if size != len(raw) || r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("invalid csv delimiter: %q", raw)
+	}
+	return r, nil
+}
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+func TestPrintAssignmentsCSV(t *testing.T) {
+	// Arrange
+	location, err := time.LoadLocation("Europe/Athens")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	crs := &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"}
+	assignments := []assignment.Assignment{
+		{
+			ID:       "24692",
+			Course:   crs,
+			Title:    `Άσκηση 1, "τμήματα Τετάρτης"`,
+			Deadline: time.Date(2022, 11, 30, 23, 55, 0, 0, location),
+		},
+		{
+			ID:       "15207",
+			Course:   crs,
+			Title:    "Άσκηση 2",
+			Deadline: time.Date(2022, 12, 7, 23, 55, 0, 0, location),
+			IsSent:   true,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		opts     config.Options
+		expected string
+	}{
+		{
+			name: "default columns",
+			opts: config.Options{},
+			expected: "courseId,courseName,id,title,deadline,submitted\n" +
+				`ICE262,ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ,24692,"Άσκηση 1, ""τμήματα Τετάρτης""",` +
+				"2022-11-30T23:55:00+02:00,false\n" +
+				"ICE262,ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ,15207,Άσκηση 2,2022-12-07T23:55:00+02:00,true\n",
+		},
+		{
+			name: "selected columns and delimiter",
+			opts: config.Options{CSVColumns: []string{"title", "deadline"}, CSVDelimiter: ";"},
+			expected: "title;deadline\n" +
+				`"Άσκηση 1, ""τμήματα Τετάρτης""";2022-11-30T23:55:00+02:00` + "\n" +
+				"Άσκηση 2;2022-12-07T23:55:00+02:00\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			var b bytes.Buffer
+			err := printAssignmentsCSV(&b, assignments, &tt.opts)
+
+			// Assert
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if b.String() != tt.expected {
+				t.Errorf("Expected:\n%v\nActual:\n%v", tt.expected, b.String())
+			}
+		})
+	}
+}
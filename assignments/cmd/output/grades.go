@@ -0,0 +1,146 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/olekukonko/tablewriter"
+)
+
+// GradesRecord is the schema of the grades of a course in the json and
+// ndjson formats.
+type GradesRecord struct {
+	CourseID    string           `json:"courseId"`
+	CourseName  string           `json:"courseName"`
+	CourseURL   string           `json:"courseUrl"`
+	Assignments []Record         `json:"assignments"`
+	Gradebook   *GradebookRecord `json:"gradebook,omitempty"`
+}
+
+// GradebookRecord is the gradebook of a GradesRecord.
+type GradebookRecord struct {
+	Activities []ActivityRecord `json:"activities"`
+	Total      string           `json:"total,omitempty"`
+}
+
+// ActivityRecord is a graded activity of a GradebookRecord.
+type ActivityRecord struct {
+	Title  string `json:"title"`
+	Grade  string `json:"grade"`
+	Weight string `json:"weight,omitempty"`
+}
+
+func newGradesRecord(grades assignment.CourseGrades, baseDomain string) (GradesRecord, error) {
+	records, err := newRecords(grades.Assignments, baseDomain)
+	if err != nil {
+		return GradesRecord{}, err
+	}
+
+	record := GradesRecord{
+		CourseID:    grades.Course.ID,
+		CourseName:  grades.Course.Name,
+		CourseURL:   grades.Course.URL,
+		Assignments: records,
+	}
+
+	if grades.Gradebook != nil {
+		activities := make([]ActivityRecord, 0, len(grades.Gradebook.Activities))
+		for _, a := range grades.Gradebook.Activities {
+			activities = append(activities, ActivityRecord(a))
+		}
+		record.Gradebook = &GradebookRecord{
+			Activities: activities,
+			Total:      grades.Gradebook.Total,
+		}
+	}
+
+	return record, nil
+}
+
+// PrintGrades prints the grades of every course to Stdout, as tables or in
+// the json and ndjson formats of opts.Format.
+func PrintGrades(grades []assignment.CourseGrades, opts *config.Options) error {
+	switch format(opts) {
+	case "table":
+		printGradesPretty(os.Stdout, grades)
+		return nil
+	case "json", "ndjson":
+		return printGradesJSON(os.Stdout, grades, opts.BaseDomain, format(opts) == "ndjson")
+	default:
+		return fmt.Errorf("grades cannot be printed in the %v format", format(opts))
+	}
+}
+
+func printGradesPretty(w io.Writer, grades []assignment.CourseGrades) {
+	if len(grades) == 0 {
+		fmt.Fprintln(w, "Δεν βρέθηκαν βαθμοί")
+		return
+	}
+
+	for i, g := range grades {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%v (%v)\n", g.Course.Name, g.Course.ID)
+
+		table := tablewriter.NewWriter(w)
+		table.SetRowLine(true)
+		table.SetHeader([]string{"ΕΡΓΑΣΙΑ", "ΒΑΘΜΟΣ", "ΒΑΘΜΟΛΟΓΗΘΗΚΕ", "ΣΧΟΛΙΑ"})
+		for _, a := range g.Assignments {
+			var gradedAt string
+			if !a.GradedAt.IsZero() {
+				gradedAt = a.GradedAt.Format("02/01/2006 15:04")
+			}
+			table.Append([]string{Title(a), Grade(a), gradedAt, a.Feedback})
+		}
+		table.Render()
+
+		if g.Gradebook == nil || len(g.Gradebook.Activities) == 0 {
+			continue
+		}
+
+		gradebook := tablewriter.NewWriter(w)
+		gradebook.SetHeader([]string{"ΒΑΘΜΟΛΟΓΙΟ", "ΒΑΘΜΟΣ", "ΒΑΡΥΤΗΤΑ"})
+		for _, a := range g.Gradebook.Activities {
+			gradebook.Append([]string{a.Title, a.Grade, a.Weight})
+		}
+		if g.Gradebook.Total != "" {
+			gradebook.SetFooter([]string{"ΣΥΝΟΛΟ", g.Gradebook.Total, ""})
+		}
+		gradebook.Render()
+	}
+}
+
+func printGradesJSON(
+	w io.Writer,
+	grades []assignment.CourseGrades,
+	baseDomain string,
+	ndjson bool,
+) error {
+	records := make([]GradesRecord, 0, len(grades))
+	for _, g := range grades {
+		record, err := newGradesRecord(g, baseDomain)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	if !ndjson {
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	}
+
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
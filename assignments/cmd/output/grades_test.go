@@ -0,0 +1,56 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+func TestPrintGradesNDJSON(t *testing.T) {
+	// Arrange
+	crs := &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"}
+	grades := []assignment.CourseGrades{
+		{
+			Course: *crs,
+			Assignments: []assignment.Assignment{
+				{
+					ID:       "24692",
+					Course:   crs,
+					Title:    "Άσκηση 1",
+					Deadline: time.Date(2022, 11, 30, 21, 55, 0, 0, time.UTC),
+					IsSent:   true,
+					Grade:    "8,5",
+					MaxGrade: "10",
+					Feedback: "Καλή δουλειά",
+				},
+			},
+			Gradebook: &course.Gradebook{
+				Activities: []course.Activity{{Title: "Άσκηση 1", Grade: "8,5", Weight: "20%"}},
+				Total:      "8,5",
+			},
+		},
+	}
+
+	expected := `{"courseId":"ICE262","courseName":"ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ","courseUrl":"",` +
+		`"assignments":[{"courseId":"ICE262","courseName":"ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ","courseUrl":"",` +
+		`"id":"24692","kind":"assignment","title":"Άσκηση 1","deadline":"2022-11-30T21:55:00Z","submitted":true,` +
+		`"url":"https://eclass.uniwa.gr/modules/work/index.php?course=ICE262&id=24692",` +
+		`"manual":false,"maxGrade":"10","grade":"8,5","feedback":"Καλή δουλειά"}],` +
+		`"gradebook":{"activities":[{"title":"Άσκηση 1","grade":"8,5","weight":"20%"}],"total":"8,5"}}` +
+		"\n"
+
+	// Act
+	var b bytes.Buffer
+	err := printGradesJSON(&b, grades, "eclass.uniwa.gr", true)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if b.String() != expected {
+		t.Errorf("Expected: %v\nActual:   %v", expected, b.String())
+	}
+}
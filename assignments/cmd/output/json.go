@@ -0,0 +1,166 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+)
+
+// Record is the schema of the json and ndjson formats. Fields are only
+// ever added to it, never renamed or removed.
+type Record struct {
+	CourseID   string `json:"courseId"`
+	CourseName string `json:"courseName"`
+	CourseURL  string `json:"courseUrl"`
+	ID         string `json:"id"`
+	// Kind is "assignment" or "exercise"
+	Kind  string `json:"kind"`
+	Title string `json:"title"`
+	// Deadline is in RFC 3339 format
+	Deadline  string `json:"deadline"`
+	Submitted bool   `json:"submitted"`
+	URL       string `json:"url"`
+	// Manual is set for the assignments added to the config file by hand
+	Manual bool `json:"manual"`
+
+	// The fields below are only present when details are fetched
+	Description string             `json:"description,omitempty"`
+	Attachments []AttachmentRecord `json:"attachments,omitempty"`
+	MaxGrade    string             `json:"maxGrade,omitempty"`
+	GroupWork   bool               `json:"groupWork,omitempty"`
+	// SubmittedAt is in RFC 3339 format
+	SubmittedAt    string             `json:"submittedAt,omitempty"`
+	SubmittedFiles []AttachmentRecord `json:"submittedFiles,omitempty"`
+
+	// Grade is only present once the assignment is graded
+	Grade string `json:"grade,omitempty"`
+	// GradedAt is in RFC 3339 format
+	GradedAt string `json:"gradedAt,omitempty"`
+	Feedback string `json:"feedback,omitempty"`
+
+	// The fields below are only present for exercises. Start is in RFC
+	// 3339 format and AttemptsAllowed is left out for unlimited attempts.
+	// AttemptsUsed is always present, even when 0.
+	Start           string `json:"start,omitempty"`
+	AttemptsAllowed int    `json:"attemptsAllowed,omitempty"`
+	AttemptsUsed    *int   `json:"attemptsUsed,omitempty"`
+}
+
+// AttachmentRecord is a file of a Record.
+type AttachmentRecord struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+func newAttachmentRecords(attachments []assignment.Attachment) []AttachmentRecord {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	records := make([]AttachmentRecord, 0, len(attachments))
+	for _, a := range attachments {
+		records = append(records, AttachmentRecord{Name: a.Name, URL: a.URL})
+	}
+	return records
+}
+
+// NewRecord is the Record of an assignment of the platform at baseDomain.
+func NewRecord(a assignment.Assignment, baseDomain string) (Record, error) {
+	assignmentURL, err := a.FullURL(baseDomain)
+	if err != nil {
+		return Record{}, err
+	}
+
+	kind := a.Kind
+	if kind == "" {
+		kind = assignment.KindAssignment
+	}
+
+	var attemptsUsed *int
+	if a.IsExercise() {
+		attemptsUsed = &a.AttemptsUsed
+	}
+
+	return Record{
+		CourseID:        a.Course.ID,
+		CourseName:      a.Course.Name,
+		CourseURL:       a.Course.URL,
+		ID:              a.ID,
+		Kind:            string(kind),
+		Title:           a.Title,
+		Deadline:        a.Deadline.Format(time.RFC3339),
+		Submitted:       a.IsSent,
+		URL:             assignmentURL,
+		Manual:          a.Manual,
+		Description:     a.Description,
+		Attachments:     newAttachmentRecords(a.Attachments),
+		MaxGrade:        a.MaxGrade,
+		GroupWork:       a.GroupWork,
+		SubmittedAt:     formatTime(a.SubmittedAt),
+		SubmittedFiles:  newAttachmentRecords(a.SubmittedFiles),
+		Grade:           a.Grade,
+		GradedAt:        formatTime(a.GradedAt),
+		Feedback:        a.Feedback,
+		Start:           formatTime(a.Start),
+		AttemptsAllowed: a.AttemptsAllowed,
+		AttemptsUsed:    attemptsUsed,
+	}, nil
+}
+
+// formatTime formats t in RFC 3339, or as nothing when it is not set.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func newRecords(assignments []assignment.Assignment, baseDomain string) ([]Record, error) {
+	records := make([]Record, 0, len(assignments))
+	for _, a := range assignments {
+		record, err := NewRecord(a, baseDomain)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func printAssignmentsJSON(
+	w io.Writer,
+	assignments []assignment.Assignment,
+	baseDomain string,
+) error {
+	records, err := newRecords(assignments, baseDomain)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+func printAssignmentsNDJSON(
+	w io.Writer,
+	assignments []assignment.Assignment,
+	baseDomain string,
+) error {
+	records, err := newRecords(assignments, baseDomain)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for _, record := range records {
+		if err = encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
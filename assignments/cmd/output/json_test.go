@@ -0,0 +1,78 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+func TestPrintAssignmentsNDJSON(t *testing.T) {
+	// Arrange
+	location, err := time.LoadLocation("Europe/Athens")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	a := assignment.Assignment{
+		ID: "24692",
+		Course: &course.Course{
+			ID:   "ICE262",
+			Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ",
+			URL:  "https://eclass.uniwa.gr/courses/ICE262/",
+		},
+		Title:    `Άσκηση 1, "τμήματα Τετάρτης"`,
+		Deadline: time.Date(2022, 11, 30, 23, 55, 0, 0, location),
+		IsSent:   true,
+	}
+
+	expected := `{"courseId":"ICE262","courseName":"ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ",` +
+		`"courseUrl":"https://eclass.uniwa.gr/courses/ICE262/","id":"24692","kind":"assignment",` +
+		`"title":"Άσκηση 1, \"τμήματα Τετάρτης\"","deadline":"2022-11-30T23:55:00+02:00",` +
+		`"submitted":true,` +
+		`"url":"https://eclass.uniwa.gr/modules/work/index.php?course=ICE262&id=24692",` +
+		`"manual":false}` +
+		"\n"
+
+	// Act
+	var b bytes.Buffer
+	err = printAssignmentsNDJSON(&b, []assignment.Assignment{a}, "eclass.uniwa.gr")
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if b.String() != expected {
+		t.Errorf("Expected: %v\nActual:   %v", expected, b.String())
+	}
+}
+
+func TestNewRecord_Exercise(t *testing.T) {
+	// Arrange
+	exercise := assignment.Assignment{
+		ID:       "7",
+		Kind:     assignment.KindExercise,
+		Course:   &course.Course{ID: "ICE262"},
+		Title:    "Quiz 1",
+		Deadline: time.Date(2022, 11, 30, 23, 55, 0, 0, time.UTC),
+	}
+
+	// Act
+	record, err := NewRecord(exercise, "eclass.uniwa.gr")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	data, err := json.Marshal(record)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !strings.Contains(string(data), `"attemptsUsed":0`) || strings.Contains(string(data), "attemptsAllowed") {
+		t.Errorf("Expected: %v, Actual: %v", "attemptsUsed 0 and unlimited attempts", string(data))
+	}
+}
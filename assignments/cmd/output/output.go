@@ -7,74 +7,150 @@ import (
 	"time"
 
 	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
 	"github.com/olekukonko/tablewriter"
 )
 
-func PrintAssignments(assignments []assignment.Assignment, plainText bool) error {
-	if plainText {
-		return printAssignmentsPlain(assignments)
+// PrintAssignments prints the assignments to Stdout through opts.Template
+// when set, otherwise in the format of opts.Format, or as csv when only
+// opts.PlainText is set.
+func PrintAssignments(assignments []assignment.Assignment, opts *config.Options) error {
+	if opts.Template != "" {
+		return printAssignmentsTemplate(os.Stdout, assignments, opts)
 	}
 
-	return printAssignmentsPretty(assignments)
+	switch format(opts) {
+	case "table":
+		return printAssignmentsPretty(assignments)
+	case "csv":
+		return printAssignmentsCSV(os.Stdout, assignments, opts)
+	case "json":
+		return printAssignmentsJSON(os.Stdout, assignments, opts.BaseDomain)
+	case "ndjson":
+		return printAssignmentsNDJSON(os.Stdout, assignments, opts.BaseDomain)
+	default:
+		return fmt.Errorf("unknown output format: %v", opts.Format)
+	}
 }
 
-func printAssignmentsPlain(assignments []assignment.Assignment) error {
-	for _, a := range assignments {
-		_, err := fmt.Println(a.String())
-		if err != nil {
-			return err
-		}
+func format(opts *config.Options) string {
+	switch {
+	case opts.Format != "":
+		return opts.Format
+	case opts.PlainText:
+		return "csv"
+	default:
+		return "table"
 	}
-	return nil
 }
 
 func printAssignmentsPretty(assignments []assignment.Assignment) error {
+	header, rows := Table(assignments)
+
+	alignment := []int{
+		tablewriter.ALIGN_DEFAULT,
+		tablewriter.ALIGN_DEFAULT,
+		tablewriter.ALIGN_DEFAULT,
+		tablewriter.ALIGN_CENTER,
+	}
+	if len(header) > len(alignment) {
+		alignment = append(alignment, tablewriter.ALIGN_CENTER)
+	}
+
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetRowLine(true)
-	table.SetHeader([]string{"ΜΑΘΗΜΑ", "ΕΡΓΑΣΙΑ", "ΠΡΟΘΕΣΜΙΑ", "ΥΠΟΒΛΗΘΗΚΕ"})
-	table.SetColumnAlignment(
-		[]int{
-			tablewriter.ALIGN_DEFAULT,
-			tablewriter.ALIGN_DEFAULT,
-			tablewriter.ALIGN_DEFAULT,
-			tablewriter.ALIGN_CENTER,
-		},
-	)
-	appendToTable(assignments, table)
+	table.SetHeader(header)
+	table.SetColumnAlignment(alignment)
+	table.AppendBulk(rows)
 	table.Render()
 
 	return nil
 }
 
-func appendToTable(assignments []assignment.Assignment, table *tablewriter.Table) {
+// Table is the header and the rows of the assignments as printed in the
+// table format, with a grade column only when any of them is graded.
+func Table(assignments []assignment.Assignment) ([]string, [][]string) {
+	graded := anyGraded(assignments)
+
+	header := []string{"ΜΑΘΗΜΑ", "ΕΡΓΑΣΙΑ", "ΠΡΟΘΕΣΜΙΑ", "ΥΠΟΒΛΗΘΗΚΕ"}
+	if graded {
+		header = append(header, "ΒΑΘΜΟΣ")
+	}
+
+	rows := make([][]string, 0, len(assignments))
 	for _, asgmt := range assignments {
-		remainingTime(asgmt)
 		var isSent string
 		if asgmt.IsSent {
 			isSent = "✓"
 		} else {
 			isSent = "✗"
 		}
-		table.Append([]string{
+		row := []string{
 			asgmt.Course.Name,
-			asgmt.Title,
-			asgmt.Deadline.Format("02/01/2006 15:04") + " " + remainingTime(asgmt),
+			Title(asgmt),
+			asgmt.Deadline.Format("02/01/2006 15:04") + " " + RemainingTime(asgmt),
 			isSent,
-		})
+		}
+		if graded {
+			row = append(row, Grade(asgmt))
+		}
+		rows = append(rows, row)
 	}
+
+	return header, rows
+}
+
+// anyGraded reports whether any of the assignments has a grade, so that
+// the table only has a grade column when it is needed.
+func anyGraded(assignments []assignment.Assignment) bool {
+	for _, a := range assignments {
+		if a.IsGraded() {
+			return true
+		}
+	}
+	return false
+}
+
+// Grade is the assignment's grade out of its max grade, when known, or -
+// when it is not graded yet.
+func Grade(a assignment.Assignment) string {
+	switch {
+	case !a.IsGraded():
+		return "-"
+	case a.MaxGrade != "":
+		return a.Grade + "/" + a.MaxGrade
+	default:
+		return a.Grade
+	}
+}
+
+// Title is the assignment's title, marked with ✎ for manual assignments
+// and with ◷ for exercises.
+func Title(a assignment.Assignment) string {
+	switch {
+	case a.Manual:
+		return "✎ " + a.Title
+	case a.IsExercise():
+		return "◷ " + a.Title
+	}
+	return a.Title
 }
 
-func remainingTime(assignment assignment.Assignment) string {
-	t := time.Until(assignment.Deadline)
+// RemainingTime describes, in Greek, how much time is left until the
+// assignment's deadline.
+func RemainingTime(assignment assignment.Assignment) string {
+	return "(" + remaining(time.Until(assignment.Deadline)) + ")"
+}
 
+func remaining(t time.Duration) string {
 	switch {
 	case t < 0:
-		return "(Έληξε)"
+		return "Έληξε"
 	case t.Hours()/24 >= 1:
-		return "(" + fmt.Sprint(math.Floor(t.Hours()/24)) + " μέρες)"
+		return fmt.Sprint(math.Floor(t.Hours()/24)) + " μέρες"
 	case t.Minutes()/60 >= 1:
-		return "(" + fmt.Sprint(math.Floor(t.Hours())) + " ώρες)"
+		return fmt.Sprint(math.Floor(t.Hours())) + " ώρες"
 	default:
-		return "(" + fmt.Sprint(math.Floor(t.Minutes())) + " λεπτά)"
+		return fmt.Sprint(math.Floor(t.Minutes())) + " λεπτά"
 	}
 }
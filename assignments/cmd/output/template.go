@@ -0,0 +1,79 @@
+package output
+
+import (
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+)
+
+// templateFuncs returns the helper functions available to user templates.
+func templateFuncs(baseDomain string) template.FuncMap {
+	return template.FuncMap{
+		// remaining describes how much time is left until t, as in the table
+		"remaining": func(t time.Time) string {
+			return remaining(time.Until(t))
+		},
+		// date formats t with a Go layout, ex. date "02/01/2006 15:04" .Deadline
+		"date": func(layout string, t time.Time) string {
+			return t.Format(layout)
+		},
+		// truncate shortens s to n characters, ending it with "…"
+		"truncate": func(n int, s string) string {
+			runes := []rune(s)
+			if n < 1 || len(runes) <= n {
+				return s
+			}
+			return string(runes[:n-1]) + "…"
+		},
+		// url is the full URL of the assignment
+		"url": func(a assignment.Assignment) (string, error) {
+			return a.FullURL(baseDomain)
+		},
+	}
+}
+
+// parseTemplate parses opts.Template, which is either the name of one of
+// opts.Templates or the template text itself.
+func parseTemplate(opts *config.Options) (*template.Template, error) {
+	text, ok := opts.Templates[opts.Template]
+	if !ok {
+		text = opts.Template
+	}
+
+	return template.New("assignment").Funcs(templateFuncs(opts.BaseDomain)).Parse(text)
+}
+
+// printAssignmentsTemplate prints every assignment through the template
+// of opts, each on its own line.
+func printAssignmentsTemplate(
+	w io.Writer,
+	assignments []assignment.Assignment,
+	opts *config.Options,
+) error {
+	tmpl, err := parseTemplate(opts)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	for _, a := range assignments {
+		b.Reset()
+		if err = tmpl.Execute(&b, a); err != nil {
+			return err
+		}
+
+		line := b.String()
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		if _, err = io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
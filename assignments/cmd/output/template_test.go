@@ -0,0 +1,45 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+func TestPrintAssignmentsTemplate(t *testing.T) {
+	// Arrange
+	a := assignment.Assignment{
+		ID:       "24692",
+		Course:   &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"},
+		Title:    "Άσκηση 1 (τμήματα Τετάρτης)",
+		Deadline: time.Date(2022, 11, 30, 23, 55, 0, 0, time.UTC),
+	}
+
+	opts := &config.Options{
+		BaseDomain: "eclass.uniwa.gr",
+		Template:   "chat",
+		Templates: map[string]string{
+			"chat": `{{ .Course.ID }}: {{ truncate 10 .Title }} ` +
+				`({{ date "02/01 15:04" .Deadline }}, {{ remaining .Deadline }}) {{ url . }}`,
+		},
+	}
+
+	expected := "ICE262: Άσκηση 1 … (30/11 23:55, Έληξε) " +
+		"https://eclass.uniwa.gr/modules/work/index.php?course=ICE262&id=24692\n"
+
+	// Act
+	var b bytes.Buffer
+	err := printAssignmentsTemplate(&b, []assignment.Assignment{a}, opts)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if b.String() != expected {
+		t.Errorf("Expected: %v\nActual:   %v", expected, b.String())
+	}
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+// row is a single line of the list; either a course header or an
+// assignment of the course above it.
+type row struct {
+	course     *course.Course
+	assignment *assignment.Assignment
+}
+
+// groupByCourse groups the assignments under their course. Courses appear
+// in the order of their closest deadline, since assignments are already
+// sorted by deadline.
+func groupByCourse(assignments []assignment.Assignment) []row {
+	order := make([]string, 0, len(assignments))
+	groups := make(map[string][]*assignment.Assignment, len(assignments))
+
+	for i := range assignments {
+		id := assignments[i].Course.ID
+		if _, ok := groups[id]; !ok {
+			order = append(order, id)
+		}
+		groups[id] = append(groups[id], &assignments[i])
+	}
+
+	rows := make([]row, 0, len(order)+len(assignments))
+	for _, id := range order {
+		group := groups[id]
+		rows = append(rows, row{course: group[0].Course})
+		for _, a := range group {
+			rows = append(rows, row{course: a.Course, assignment: a})
+		}
+	}
+
+	return rows
+}
+
+func firstAssignmentRow(rows []row) int {
+	for i, r := range rows {
+		if r.assignment != nil {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+func TestGroupByCourse(t *testing.T) {
+	// Arrange
+	ice := &course.Course{ID: "ICE262"}
+	cs := &course.Course{ID: "CS152"}
+	assignments := []assignment.Assignment{
+		{ID: "1", Course: ice},
+		{ID: "2", Course: cs},
+		{ID: "3", Course: ice},
+	}
+
+	// Act
+	rows := groupByCourse(assignments)
+
+	// Assert
+	expected := []string{"ICE262", "1", "3", "CS152", "2"}
+	if len(rows) != len(expected) {
+		t.Fatalf("Expected: %v, Actual: %v", len(expected), len(rows))
+	}
+	for i, r := range rows {
+		actual := r.course.ID
+		if r.assignment != nil {
+			actual = r.assignment.ID
+			if r.course != r.assignment.Course {
+				t.Errorf("Expected: %v, Actual: %v", r.assignment.Course.ID, r.course.ID)
+			}
+		}
+		if actual != expected[i] {
+			t.Errorf("Expected: %v, Actual: %v", expected[i], actual)
+		}
+	}
+}
+
+func TestFirstAssignmentRow(t *testing.T) {
+	// Arrange
+	rows := groupByCourse([]assignment.Assignment{
+		{ID: "1", Course: &course.Course{ID: "ICE262"}},
+	})
+
+	// Act
+	res := firstAssignmentRow(rows)
+
+	// Assert
+	if res != 1 {
+		t.Errorf("Expected: %v, Actual: %v", 1, res)
+	}
+}
@@ -0,0 +1,306 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/connect"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/snapshot"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Run starts the interactive assignments browser. Assignments are fetched
+// through snapshot.Fetch, the same pipeline the table output uses.
+func Run(opts *config.Options, creds *config.Credentials) error {
+	// quitting cancels a fetch that is still running
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := tea.NewProgram(newModel(ctx, opts, creds), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
+
+type fetchedMsg struct {
+	snap *snapshot.Snapshot
+	err  error
+}
+
+type openedMsg struct {
+	err error
+}
+
+type model struct {
+	ctx   context.Context
+	opts  *config.Options
+	creds *config.Credentials
+
+	assignments []assignment.Assignment
+	snap        *snapshot.Snapshot
+	filter      assignment.Filter
+	// expiredByFilter is set while the Expired filter is what includes the
+	// expired assignments, so that turning it off leaves them out again.
+	expiredByFilter bool
+	searching       bool
+	rows            []row
+	cursor          int
+	offset          int
+
+	loading bool
+	err     error
+	status  string
+
+	width  int
+	height int
+}
+
+func newModel(ctx context.Context, opts *config.Options, creds *config.Credentials) model {
+	return model{
+		ctx:     ctx,
+		opts:    opts,
+		creds:   creds,
+		loading: true,
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return fetch(m.ctx, *m.opts, *m.creds)
+}
+
+func fetch(ctx context.Context, opts config.Options, creds config.Credentials) tea.Cmd {
+	return func() tea.Msg {
+		client, err := connect.NewClient(snapshot.Unfiltered(opts), creds)
+		if err != nil {
+			return fetchedMsg{err: err}
+		}
+
+		snap, err := snapshot.Fetch(ctx, &opts, client)
+		return fetchedMsg{snap: snap, err: err}
+	}
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scroll()
+	case fetchedMsg:
+		m.loading = false
+		var partial *assignment.PartialError
+		if errors.As(msg.err, &partial) && msg.snap != nil {
+			m.status = fmt.Sprintf("%v μάθημα(τα) απέτυχαν", len(partial.Errors))
+			msg.err = nil
+		}
+		if msg.err != nil && len(m.rows) > 0 {
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		m.err = msg.err
+		if msg.err == nil {
+			m.snap = msg.snap
+			m.reload()
+		}
+	case openedMsg:
+		if msg.err != nil {
+			m.status = msg.err.Error()
+		}
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+// reload takes the assignments from the snapshot again, after the options
+// changed.
+func (m *model) reload() {
+	assignments, err := m.snap.Filter(m.opts)
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+
+	m.assignments = assignments
+	m.applyFilter()
+}
+
+// applyFilter rebuilds the list from the fetched assignments that pass the
+// current filter.
+func (m *model) applyFilter() {
+	filtered := m.filter.Apply(m.assignments, m.opts, time.Now())
+	m.rows = groupByCourse(filtered)
+	m.cursor = firstAssignmentRow(m.rows)
+	m.offset = 0
+	m.scroll()
+}
+
+func (m model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEnter:
+		m.searching = false
+		return m, nil
+	case tea.KeyEsc:
+		m.searching = false
+		m.filter.Query = ""
+	case tea.KeyBackspace:
+		query := []rune(m.filter.Query)
+		if len(query) == 0 {
+			return m, nil
+		}
+		m.filter.Query = string(query[:len(query)-1])
+	case tea.KeySpace:
+		m.filter.Query += " "
+	case tea.KeyRunes:
+		m.filter.Query += string(msg.Runes)
+	default:
+		return m, nil
+	}
+
+	m.applyFilter()
+	return m, nil
+}
+
+func (m model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
+
+	if m.searching {
+		return m.handleSearchKey(msg)
+	}
+
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "/":
+		m.searching = true
+	case "esc":
+		m.filter = assignment.Filter{}
+		if m.expiredByFilter {
+			m.opts.IncludeExpired = false
+			m.expiredByFilter = false
+			m.reload()
+			return m, nil
+		}
+		m.applyFilter()
+	case "s":
+		m.filter.NotSubmitted = !m.filter.NotSubmitted
+		m.applyFilter()
+	case "w":
+		m.filter.DueThisWeek = !m.filter.DueThisWeek
+		m.applyFilter()
+	case "x":
+		m.filter.Expired = !m.filter.Expired
+		switch {
+		case m.filter.Expired && !m.opts.IncludeExpired && m.snap != nil:
+			m.opts.IncludeExpired = true
+			m.expiredByFilter = true
+			m.reload()
+		case !m.filter.Expired && m.expiredByFilter:
+			m.opts.IncludeExpired = false
+			m.expiredByFilter = false
+			m.reload()
+		default:
+			m.applyFilter()
+		}
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup", "ctrl+u":
+		m.move(-m.listHeight())
+	case "pgdown", "ctrl+d":
+		m.move(m.listHeight())
+	case "home", "g":
+		m.move(-len(m.rows))
+	case "end", "G":
+		m.move(len(m.rows))
+	case "r":
+		if m.loading {
+			return m, nil
+		}
+		m.loading = true
+		return m, fetch(m.ctx, *m.opts, *m.creds)
+	case "e":
+		if m.snap == nil {
+			return m, nil
+		}
+		m.opts.IncludeExpired = !m.opts.IncludeExpired
+		m.expiredByFilter = false
+		m.reload()
+	case "o", "enter":
+		a, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		return m, openAssignment(a, m.opts.BaseDomain)
+	}
+
+	return m, nil
+}
+
+// move shifts the cursor by delta assignment rows, skipping course headers.
+func (m *model) move(delta int) {
+	if len(m.rows) == 0 {
+		return
+	}
+
+	step := 1
+	if delta < 0 {
+		step, delta = -1, -delta
+	}
+
+	cursor := m.cursor
+	for i := cursor + step; i >= 0 && i < len(m.rows) && delta > 0; i += step {
+		if m.rows[i].assignment != nil {
+			cursor = i
+			delta--
+		}
+	}
+	m.cursor = cursor
+	m.scroll()
+}
+
+// scroll keeps the cursor, and the header of its course when possible,
+// inside the visible part of the list.
+func (m *model) scroll() {
+	height := m.listHeight()
+	if height <= 0 {
+		return
+	}
+
+	top := m.cursor
+	if top > 0 && m.rows[top-1].assignment == nil {
+		top--
+	}
+	if top < m.offset {
+		m.offset = top
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+}
+
+func (m model) selected() (assignment.Assignment, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.rows) || m.rows[m.cursor].assignment == nil {
+		return assignment.Assignment{}, false
+	}
+	return *m.rows[m.cursor].assignment, true
+}
+
+func openAssignment(a assignment.Assignment, baseDomain string) tea.Cmd {
+	return func() tea.Msg {
+		assignmentURL, err := a.FullURL(baseDomain)
+		if err != nil {
+			return openedMsg{err: err}
+		}
+		if assignmentURL == "" {
+			return openedMsg{err: errors.New("η εργασία δεν έχει σύνδεσμο")}
+		}
+		return openedMsg{err: openBrowser(assignmentURL)}
+	}
+}
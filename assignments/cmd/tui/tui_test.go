@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/snapshot"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// listModel is a model whose list shows height rows of two courses with
+// two assignments each:
+//
+//	0 ICE262, 1 "1", 2 "2", 3 CS152, 4 "3", 5 "4"
+func listModel(height int) model {
+	ice := &course.Course{ID: "ICE262"}
+	cs := &course.Course{ID: "CS152"}
+	m := newModel(context.Background(), &config.Options{}, &config.Credentials{})
+	m.rows = groupByCourse([]assignment.Assignment{
+		{ID: "1", Course: ice},
+		{ID: "2", Course: ice},
+		{ID: "3", Course: cs},
+		{ID: "4", Course: cs},
+	})
+	m.cursor = firstAssignmentRow(m.rows)
+	m.height = height + detailPaneHeight + 3
+	return m
+}
+
+func TestModelMove(t *testing.T) {
+	tests := []struct {
+		name     string
+		cursor   int
+		delta    int
+		expected int
+	}{
+		{name: "down", cursor: 1, delta: 1, expected: 2},
+		{name: "down over header", cursor: 2, delta: 1, expected: 4},
+		{name: "up over header", cursor: 4, delta: -1, expected: 2},
+		{name: "past the end", cursor: 4, delta: 10, expected: 5},
+		{name: "past the start", cursor: 4, delta: -10, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			m := listModel(10)
+			m.cursor = tt.cursor
+
+			// Act
+			m.move(tt.delta)
+
+			// Assert
+			if m.cursor != tt.expected {
+				t.Errorf("Expected: %v, Actual: %v", tt.expected, m.cursor)
+			}
+		})
+	}
+}
+
+func TestModelScroll(t *testing.T) {
+	tests := []struct {
+		name     string
+		offset   int
+		cursor   int
+		expected int
+	}{
+		{name: "visible", offset: 0, cursor: 2, expected: 0},
+		{name: "below", offset: 0, cursor: 5, expected: 3},
+		{name: "above with header", offset: 3, cursor: 1, expected: 0},
+		{name: "above", offset: 3, cursor: 2, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			m := listModel(3)
+			m.offset = tt.offset
+			m.cursor = tt.cursor
+
+			// Act
+			m.scroll()
+
+			// Assert
+			if m.offset != tt.expected {
+				t.Errorf("Expected: %v, Actual: %v", tt.expected, m.offset)
+			}
+		})
+	}
+}
+
+func TestModelHandleKey_ExpiredFilter(t *testing.T) {
+	// Arrange
+	crs := &course.Course{ID: "ICE262"}
+	now := time.Now()
+	m := listModel(10)
+	m.snap = &snapshot.Snapshot{
+		Courses: []course.Course{*crs},
+		Assignments: []assignment.Assignment{
+			{ID: "1", Course: crs, Deadline: now.AddDate(0, 0, -1)},
+			{ID: "2", Course: crs, Deadline: now.AddDate(0, 0, 1)},
+		},
+	}
+	m.reload()
+	x := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+
+	// Act
+	on, _ := m.handleKey(x)
+	included := on.(model).opts.IncludeExpired
+	off, _ := on.(model).handleKey(x)
+
+	// Assert
+	if !included || len(on.(model).assignments) != 2 {
+		t.Errorf("Expected: %v, Actual: %v", 2, len(on.(model).assignments))
+	}
+	if off.(model).opts.IncludeExpired || len(off.(model).assignments) != 1 {
+		t.Errorf("Expected: %v, Actual: %v", 1, len(off.(model).assignments))
+	}
+}
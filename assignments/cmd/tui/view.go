@@ -0,0 +1,277 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/output"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	detailPaneHeight = 7
+	// detailsPaneHeight is the height of the detail pane when the details
+	// of the assignments are fetched.
+	detailsPaneHeight = 11
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Reverse(true).Padding(0, 1)
+	courseStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11"))
+	expiredStyle  = lipgloss.NewStyle().Faint(true)
+	labelStyle    = lipgloss.NewStyle().Bold(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	helpStyle     = lipgloss.NewStyle().Faint(true)
+	detailStyle   = lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderTop(true)
+)
+
+// listHeight is the number of rows left for the list after the title,
+// the filter line, the detail pane and the help line.
+func (m model) listHeight() int {
+	return m.height - m.detailHeight() - 3
+}
+
+func (m model) detailHeight() int {
+	if m.opts.FetchDetails {
+		return detailsPaneHeight
+	}
+	return detailPaneHeight
+}
+
+func (m model) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.viewTitle())
+	b.WriteString("\n")
+	b.WriteString(m.viewFilter())
+	b.WriteString("\n")
+
+	switch {
+	case m.loading && len(m.rows) == 0:
+		b.WriteString("Φόρτωση εργασιών...")
+	case m.err != nil:
+		b.WriteString(errorStyle.Render(m.err.Error()))
+	case len(m.rows) == 0:
+		b.WriteString("Δεν βρέθηκαν εργασίες")
+	default:
+		b.WriteString(m.viewList())
+		b.WriteString("\n")
+		b.WriteString(m.viewDetail())
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.viewHelp())
+
+	return b.String()
+}
+
+func (m model) viewTitle() string {
+	title := "ΕΡΓΑΣΙΕΣ"
+	if m.opts.IncludeExpired {
+		title += " (με ληγμένες)"
+	}
+	if m.snap != nil && m.snap.Offline {
+		title += fmt.Sprintf(
+			" • εκτός σύνδεσης, δεδομένα της %v",
+			m.snap.FetchedAt.Format("02/01/2006 15:04"),
+		)
+	}
+	if m.loading {
+		title += " • ανανέωση..."
+	}
+	return titleStyle.Render(title)
+}
+
+func (m model) viewFilter() string {
+	parts := make([]string, 0, 4)
+
+	if m.searching || m.filter.Query != "" {
+		cursor := ""
+		if m.searching {
+			cursor = "█"
+		}
+		parts = append(parts, "/"+m.filter.Query+cursor)
+	}
+	if m.filter.NotSubmitted {
+		parts = append(parts, "[μη υποβληθείσες]")
+	}
+	if m.filter.DueThisWeek {
+		parts = append(parts, "[αυτή την εβδομάδα]")
+	}
+	if m.filter.Expired {
+		parts = append(parts, "[ληγμένες]")
+	}
+
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(strings.Join(parts, " "))
+}
+
+func (m model) viewList() string {
+	height := m.listHeight()
+	if height < 1 {
+		height = 1
+	}
+
+	end := m.offset + height
+	if end > len(m.rows) {
+		end = len(m.rows)
+	}
+
+	lines := make([]string, 0, height)
+	for i := m.offset; i < end; i++ {
+		lines = append(lines, m.viewRow(i))
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m model) viewRow(i int) string {
+	r := m.rows[i]
+	line := lipgloss.NewStyle().MaxWidth(m.width)
+
+	if r.assignment == nil {
+		return line.Render(courseStyle.Render(fmt.Sprintf("%v (%v)", r.course.Name, r.course.ID)))
+	}
+
+	a := r.assignment
+	text := fmt.Sprintf(
+		"%v %v  %v %v",
+		isSentMark(*a),
+		a.Deadline.Format("02/01/2006 15:04"),
+		output.Title(*a),
+		output.RemainingTime(*a),
+	)
+
+	switch {
+	case i == m.cursor:
+		return line.Render(selectedStyle.Render("> " + text))
+	case output.RemainingTime(*a) == "(Έληξε)":
+		return line.Render(expiredStyle.Render("  " + text))
+	default:
+		return line.Render("  " + text)
+	}
+}
+
+func (m model) viewDetail() string {
+	a, ok := m.selected()
+	if !ok {
+		return detailStyle.Width(m.width).Height(m.detailHeight() - 1).Render("")
+	}
+
+	assignmentURL, err := a.FullURL(m.opts.BaseDomain)
+	if err != nil {
+		assignmentURL = err.Error()
+	}
+
+	lines := []string{
+		labelStyle.Render("ΜΑΘΗΜΑ: ") + fmt.Sprintf("%v (%v)", a.Course.Name, a.Course.ID),
+		labelStyle.Render("ΕΡΓΑΣΙΑ: ") + output.Title(a),
+		labelStyle.Render("ΠΡΟΘΕΣΜΙΑ: ") +
+			a.Deadline.Format("02/01/2006 15:04") + " " + output.RemainingTime(a),
+		labelStyle.Render("ΥΠΟΒΛΗΘΗΚΕ: ") + isSentMark(a),
+		labelStyle.Render("URL: ") + assignmentURL,
+	}
+	if a.IsGraded() {
+		lines = append(lines, labelStyle.Render("ΒΑΘΜΟΣ: ")+gradeLine(a))
+	}
+	if a.IsExercise() {
+		lines = append(lines, labelStyle.Render("ΑΣΚΗΣΗ: ")+exerciseLine(a))
+	}
+	if m.opts.FetchDetails && !a.Manual && !a.IsExercise() {
+		lines = append(lines, detailLines(a)...)
+	}
+
+	return detailStyle.
+		Width(m.width).
+		Height(m.detailHeight() - 1).
+		MaxWidth(m.width).
+		Render(strings.Join(lines, "\n"))
+}
+
+func (m model) viewHelp() string {
+	if m.status != "" {
+		return errorStyle.Render(m.status)
+	}
+	if m.searching {
+		return helpStyle.Render("enter εφαρμογή • esc καθαρισμός")
+	}
+	return helpStyle.Render(
+		"↑/↓ μετακίνηση • / αναζήτηση • s μη υποβληθείσες • w εβδομάδα • " +
+			"x ληγμένες • o άνοιγμα • r ανανέωση • e συμπερίληψη ληγμένων • q έξοδος",
+	)
+}
+
+// detailLines describes the fields of a that are only filled in when the
+// details are fetched, one line each.
+func detailLines(a assignment.Assignment) []string {
+	kind := "Ατομική"
+	if a.GroupWork {
+		kind = "Ομαδική"
+	}
+
+	submission := "-"
+	if !a.SubmittedAt.IsZero() {
+		submission = a.SubmittedAt.Format("02/01/2006 15:04")
+	}
+	if len(a.SubmittedFiles) > 0 {
+		submission += " " + attachmentNames(a.SubmittedFiles)
+	}
+
+	return []string{
+		labelStyle.Render("ΠΕΡΙΓΡΑΦΗ: ") + strings.Join(strings.Fields(a.Description), " "),
+		labelStyle.Render("ΣΥΝΗΜΜΕΝΑ: ") + attachmentNames(a.Attachments),
+		labelStyle.Render("ΜΕΓ. ΒΑΘΜΟΣ: ") + a.MaxGrade + "  " +
+			labelStyle.Render("ΤΥΠΟΣ: ") + kind,
+		labelStyle.Render("ΥΠΟΒΟΛΗ: ") + submission,
+	}
+}
+
+func gradeLine(a assignment.Assignment) string {
+	line := output.Grade(a)
+	if !a.GradedAt.IsZero() {
+		line += " (" + a.GradedAt.Format("02/01/2006") + ")"
+	}
+	if a.Feedback != "" {
+		line += " " + strings.Join(strings.Fields(a.Feedback), " ")
+	}
+	return line
+}
+
+// exerciseLine describes the time window and the attempts of an exercise.
+func exerciseLine(a assignment.Assignment) string {
+	line := "έναρξη -"
+	if !a.Start.IsZero() {
+		line = "έναρξη " + a.Start.Format("02/01/2006 15:04")
+	}
+
+	allowed := "∞"
+	if a.AttemptsAllowed > 0 {
+		allowed = fmt.Sprint(a.AttemptsAllowed)
+	}
+	return fmt.Sprintf("%v, προσπάθειες %v/%v", line, a.AttemptsUsed, allowed)
+}
+
+func attachmentNames(attachments []assignment.Attachment) string {
+	if len(attachments) == 0 {
+		return "-"
+	}
+
+	names := make([]string, 0, len(attachments))
+	for _, a := range attachments {
+		names = append(names, a.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func isSentMark(a assignment.Assignment) string {
+	if a.IsSent {
+		return "✓"
+	}
+	return "✗"
+}
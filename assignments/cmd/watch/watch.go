@@ -0,0 +1,127 @@
+package watch
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/cmd/notify"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/reminder"
+	"github.com/Huray-hub/eclass-utils/assignments/watch"
+)
+
+// Run polls the assignments until interrupted and prints the new, changed
+// and removed ones as they are found, along with the reminders of the
+// deadlines.
+//
+//	watch [-interval=30m] [-jitter=5m] [-once]
+func Run(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	opts, creds := &cfg.Options, &cfg.Credentials
+
+	// config files older than the watch command have no interval
+	if opts.WatchInterval <= 0 {
+		opts.WatchInterval = watch.DefaultInterval
+	}
+
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	interval := fs.Duration("interval", opts.WatchInterval, "Time between polls (ex. 30m, 1h)")
+	jitter := fs.Duration("jitter", opts.WatchJitter, "Random delay of up to this much added to every interval")
+	once := fs.Bool("once", false, "Poll once and exit, ex. when run by cron")
+	if err = fs.Parse(args); err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("invalid interval: %v", *interval)
+	}
+
+	err = config.Ensure(opts, creds)
+	if err != nil {
+		return err
+	}
+
+	statePath, err := watch.StatePath()
+	if err != nil {
+		return err
+	}
+
+	reminderPath, err := reminder.StatePath()
+	if err != nil {
+		return err
+	}
+	reminders, err := reminder.New(opts, reminderPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	notifiers, err := newNotifiers(cfg)
+	if err != nil {
+		return err
+	}
+
+	w := &watch.Watcher{
+		Fetch: func(ctx context.Context) ([]assignment.Assignment, error) {
+			return assignment.GetContext(ctx, opts, creds)
+		},
+		Interval:  *interval,
+		Jitter:    *jitter,
+		StatePath: statePath,
+		Notifiers: notifiers,
+		Reminders: reminders,
+	}
+
+	if *once {
+		_, err = w.Poll(ctx)
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Watching the assignments every %v, press Ctrl+C to stop\n", *interval)
+	return w.Run(ctx)
+}
+
+// newNotifiers returns the notifiers of the watch command, which prints the
+// notifications and sends them to the configured backends and bot as well.
+func newNotifiers(cfg *config.Config) ([]watch.Notifier, error) {
+	notifiers := []watch.Notifier{watch.WriterNotifier{W: os.Stdout}}
+
+	if cfg.Notifications.Email.Host != "" {
+		statePath, err := notify.DigestStatePath()
+		if err != nil {
+			return nil, err
+		}
+		email, err := notify.NewEmail(cfg.Notifications.Email, statePath)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, email)
+	}
+
+	for _, webhookCfg := range cfg.Notifications.Webhooks {
+		webhook, err := notify.NewWebhook(webhookCfg, cfg.Options.BaseDomain)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, webhook)
+	}
+
+	b, err := notify.NewBot(cfg.Bot, cfg.Options.BaseDomain)
+	if err != nil {
+		return nil, err
+	}
+	if b != nil {
+		notifiers = append(notifiers, b)
+	}
+
+	return notifiers, nil
+}
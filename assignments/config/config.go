@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	slashpath "path"
 	"path/filepath"
 	"strings"
 	"syscall"
@@ -15,8 +16,53 @@ import (
 )
 
 type Config struct {
-	Credentials Credentials `yaml:"credentials"`
-	Options     Options     `yaml:"options"`
+	Credentials   Credentials   `yaml:"credentials"`
+	Options       Options       `yaml:"options"`
+	Notifications Notifications `yaml:"notifications"`
+	Bot           Bot           `yaml:"bot"`
+}
+
+// Bot configures the bot command, which answers about the assignments in
+// chat rooms, and the watch command, which posts its notifications there.
+type Bot struct {
+	Telegram Telegram `yaml:"telegram"`
+}
+
+// Telegram configures the bot on Telegram, which is off while Token is
+// empty.
+type Telegram struct {
+	Token string `yaml:"token"`
+	// APIURL is the Bot API server, https://api.telegram.org if not set.
+	APIURL string `yaml:"apiURL"`
+	// Chats are the IDs of the chats the bot answers in and posts to. The
+	// bot ignores every other chat.
+	Chats []string `yaml:"chats"`
+}
+
+// Notifications configures where the watch command sends its
+// notifications, besides printing them.
+type Notifications struct {
+	Email    Email     `yaml:"email"`
+	Webhooks []Webhook `yaml:"webhooks"`
+}
+
+// Email configures the notifications by mail, which are off while Host is
+// empty.
+type Email struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	// StartTLS upgrades the connection to TLS before logging in, and fails
+	// when the server does not support it.
+	StartTLS bool     `yaml:"startTLS"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+	// Immediate mails every new assignment as soon as it is found.
+	Immediate bool `yaml:"immediate"`
+	// DigestTime is the time of day, as 15:04 in Greek local time, of a
+	// daily mail with the upcoming deadlines. Empty turns the digest off.
+	DigestTime string `yaml:"digestTime"`
 }
 
 type Credentials struct {
@@ -27,32 +73,240 @@ type Credentials struct {
 type Options struct {
 	BaseDomain          string              `yaml:"baseDomain"`
 	PlainText           bool                `yaml:"plainText"`
+	Format              string              `yaml:"format"`
+	CSVColumns          []string            `yaml:"csvColumns"`
+	CSVDelimiter        string              `yaml:"csvDelimiter"`
+	Template            string              `yaml:"template"`
+	Templates           map[string]string   `yaml:"templates"`
+	Interactive         bool                `yaml:"interactive"`
 	IncludeExpired      bool                `yaml:"includeExpired"`
 	ExportICS           bool                `yaml:"exportICS"`
+	FetchDetails        bool                `yaml:"fetchDetails"`
 	ExcludedCourses     map[string]struct{} `yaml:"excludedCourses"`
 	ExcludedAssignments map[string][]string `yaml:"excludedAssignments"`
+	Parallelism         int                 `yaml:"parallelism"`
+	RequestDelay        time.Duration       `yaml:"requestDelay"`
+	Offline             bool                `yaml:"-"`
+	ManualAssignments   []ManualAssignment  `yaml:"manualAssignments"`
+	// WatchInterval is the time between polls of the watch command, to
+	// which a random delay of up to WatchJitter is added.
+	WatchInterval time.Duration `yaml:"watchInterval"`
+	WatchJitter   time.Duration `yaml:"watchJitter"`
+	// Reminders are the lead times before a deadline, like "7d" or "3h",
+	// at which the watch command reminds of the assignments not submitted
+	// yet. CourseReminders replaces them for the courses it has.
+	Reminders       []string            `yaml:"reminders"`
+	CourseReminders map[string][]string `yaml:"courseReminders"`
+	// DocumentRules picks the documents to sync by course ID, with the
+	// rules under "*" applying to every course.
+	DocumentRules map[string]DocumentRules `yaml:"documentRules"`
+}
+
+// DocumentRules picks the documents of a course by glob patterns (see
+// path.Match) on their path, on any of their folders or on their name.
+type DocumentRules struct {
+	// Include keeps only the documents that match, if set.
+	Include []string `yaml:"include"`
+	// Exclude leaves out the documents that match.
+	Exclude []string `yaml:"exclude"`
+}
+
+// Webhook is a URL that the notifications are posted to.
+type Webhook struct {
+	URL string `yaml:"url"`
+	// Format is "json" for the JSON payload of the changes, or "chat" for a
+	// text message that Slack and Discord webhooks accept.
+	Format string `yaml:"format"`
+	// Digest posts the changes of every poll together instead of one by
+	// one.
+	Digest bool `yaml:"digest"`
+	// Secret signs every body with HMAC-SHA256, when set.
+	Secret string `yaml:"secret"`
+	// Retries is the number of retries of a failed post, 3 if not set and
+	// none if negative.
+	Retries int `yaml:"retries"`
+	// ExcludedCourses leaves out the changes of these courses, as the
+	// ExcludedCourses option does.
+	ExcludedCourses map[string]struct{} `yaml:"excludedCourses"`
+}
+
+// IsCourseExcluded reports whether the course with the given ID is
+// excluded from the webhook.
+func (w *Webhook) IsCourseExcluded(courseID string) bool {
+	_, ok := w.ExcludedCourses[courseID]
+	return ok
+}
+
+// ManualAssignment is an assignment added by hand, for deadlines that are
+// posted outside of e-class.
+type ManualAssignment struct {
+	ID       string `yaml:"id"`
+	CourseID string `yaml:"courseId"`
+	Title    string `yaml:"title"`
+	// Deadline is in Greek local time, formatted as ManualDeadlineLayout
+	Deadline  string `yaml:"deadline"`
+	URL       string `yaml:"url,omitempty"`
+	Submitted bool   `yaml:"submitted"`
+}
+
+// ManualDeadlineLayout is the format of ManualAssignment.Deadline.
+const ManualDeadlineLayout = "2006-01-02 15:04"
+
+// IsCourseExcluded reports whether the course with the given ID is
+// excluded through the ExcludedCourses option.
+func (opts *Options) IsCourseExcluded(courseID string) bool {
+	_, ok := opts.ExcludedCourses[courseID]
+	return ok
+}
+
+// IsAssignmentExcluded reports whether an assignment's title contains
+// any of the keywords excluded for its course through the
+// ExcludedAssignments option.
+func (opts *Options) IsAssignmentExcluded(courseID, title string) bool {
+	for _, v := range opts.ExcludedAssignments[courseID] {
+		if strings.Contains(title, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDocumentIncluded reports whether the document at the slash separated
+// docPath of a course is picked by the DocumentRules option.
+func (opts *Options) IsDocumentIncluded(courseID, docPath string) bool {
+	for _, key := range []string{"*", courseID} {
+		rules, ok := opts.DocumentRules[key]
+		if !ok {
+			continue
+		}
+		if len(rules.Include) > 0 && !matchesAny(rules.Include, docPath) {
+			return false
+		}
+		if matchesAny(rules.Exclude, docPath) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAny reports whether any of the patterns matches the name of
+// docPath or of one of its folders, or the path up to any of them.
+func matchesAny(patterns []string, docPath string) bool {
+	segments := strings.Split(docPath, "/")
+	candidates := append([]string{}, segments...)
+	for i := 1; i < len(segments); i++ {
+		candidates = append(candidates, strings.Join(segments[:i+1], "/"))
+	}
+
+	for _, pattern := range patterns {
+		for _, candidate := range candidates {
+			if ok, _ := slashpath.Match(pattern, candidate); ok {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // Import function will read options and credentials from the
 // config.yaml file. If the config file is missing, it will
 // be created with default values.
 func Import() (*Options, *Credentials, error) {
-	configPath, err := path()
+	config, err := Load()
 	if err != nil {
 		return nil, nil, err
 	}
 
+	return &config.Options, &config.Credentials, nil
+}
+
+// Load reads the whole config file, creating it with default values when
+// missing.
+func Load() (*Config, error) {
+	configPath, err := path()
+	if err != nil {
+		return nil, err
+	}
+
 	yamlFile, err := os.ReadFile(configPath)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	config, err := decodeYaml(yamlFile)
+	return decodeYaml(yamlFile)
+}
+
+// Save writes cfg to the config file, keeping the comments and the order
+// of the keys that are already in it.
+func Save(cfg *Config) error {
+	configPath, err := path()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	return &config.Options, &config.Credentials, nil
+	yamlFile, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	err = yaml.Unmarshal(yamlFile, &doc)
+	if err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		return createConfig(configPath, cfg)
+	}
+
+	var updated yaml.Node
+	err = updated.Encode(cfg)
+	if err != nil {
+		return err
+	}
+	mergeNode(doc.Content[0], &updated)
+
+	yamlFile, err = yaml.Marshal(&doc)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(configPath, yamlFile, 0644)
+}
+
+// mergeNode sets the values of src to dst, keeping the comments of dst.
+// Mappings are merged key by key, so that the keys of dst keep their
+// order and comments, while the keys missing from src are dropped.
+func mergeNode(dst, src *yaml.Node) {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		head, line, foot := dst.HeadComment, dst.LineComment, dst.FootComment
+		*dst = *src
+		dst.HeadComment, dst.LineComment, dst.FootComment = head, line, foot
+		return
+	}
+
+	content := make([]*yaml.Node, 0, len(src.Content))
+	for i := 0; i+1 < len(dst.Content); i += 2 {
+		if j := keyIndex(src, dst.Content[i].Value); j >= 0 {
+			mergeNode(dst.Content[i+1], src.Content[j+1])
+			content = append(content, dst.Content[i], dst.Content[i+1])
+		}
+	}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		if keyIndex(dst, src.Content[i].Value) < 0 {
+			content = append(content, src.Content[i], src.Content[i+1])
+		}
+	}
+	dst.Content = content
+}
+
+// keyIndex is the index of key among the keys of the mapping node, or -1.
+func keyIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
 }
 
 func decodeYaml(yamlFile []byte) (*Config, error) {
@@ -79,20 +333,18 @@ func Ensure(opts *Options, creds *Credentials) error {
 	}
 
 	if updateOpts || updateCreds {
-		configDir, err := os.UserConfigDir()
+		// the rest of the config file, like the notifications, is kept
+		cfg, err := Load()
 		if err != nil {
 			return err
 		}
-		path := filepath.Join(configDir, "eclass-utils", "config.yaml")
 
-		cfg := &Config{Options: *opts}
+		cfg.Options = *opts
 		if updateCreds {
 			cfg.Credentials = *creds
-		} else {
-			cfg.Credentials = *newDefaultCredentials()
 		}
 
-		err = createConfig(path, cfg)
+		err = Save(cfg)
 		if err != nil {
 			return err
 		}
@@ -101,6 +353,13 @@ func Ensure(opts *Options, creds *Credentials) error {
 }
 
 func ensureOptions(opts *Options) (bool, error) {
+	// A configured domain is not probed over the network, so that an
+	// unreachable server falls back to the offline snapshot instead of
+	// prompting for another domain.
+	if opts.BaseDomain != "" && isValidDomainName(opts.BaseDomain) {
+		return false, nil
+	}
+
 	updateDomain := false
 	for opts.BaseDomain == "" || !isValidDomain(opts.BaseDomain) {
 		err := inputStdin(&opts.BaseDomain, "Domain")
@@ -113,8 +372,12 @@ func ensureOptions(opts *Options) (bool, error) {
 	return updateDomain, nil
 }
 
+func isValidDomainName(baseDomain string) bool {
+	return strings.Contains(baseDomain, ".gr") && strings.Contains(baseDomain, "eclass")
+}
+
 func isValidDomain(baseDomain string) bool {
-	if !strings.Contains(baseDomain, ".gr") || !strings.Contains(baseDomain, "eclass") {
+	if !isValidDomainName(baseDomain) {
 		fmt.Println("Invalid domain. Try eclass.<yourcollege>.gr")
 		return false
 	}
@@ -203,6 +466,25 @@ func inputPasswordStdin(password *string) error {
 	return nil
 }
 
+// CacheDir returns the directory that holds the logs and every other file
+// the tools keep between runs, creating it when missing.
+func CacheDir() (string, error) {
+	homeCache, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(homeCache, "eclass-utils")
+	if _, err = os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		err = os.MkdirAll(path, 0755)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return path, nil
+}
+
 func path() (string, error) {
 	homeConfig, err := os.UserConfigDir()
 	if err != nil {
@@ -245,10 +527,27 @@ func newDefault() *Config {
 		Options: Options{
 			BaseDomain:          "",
 			PlainText:           false,
+			Format:              "",
+			Interactive:         false,
 			IncludeExpired:      false,
 			ExportICS:           false,
+			FetchDetails:        false,
 			ExcludedCourses:     map[string]struct{}{},
 			ExcludedAssignments: map[string][]string{},
+			Templates:           map[string]string{},
+			Parallelism:         4,
+			RequestDelay:        0,
+			WatchInterval:       30 * time.Minute,
+			WatchJitter:         5 * time.Minute,
+			Reminders:           []string{"7d", "1d", "3h"},
+			CourseReminders:     map[string][]string{},
+		},
+		Notifications: Notifications{
+			Email: Email{
+				Port:      587,
+				StartTLS:  true,
+				Immediate: true,
+			},
 		},
 	}
 }
@@ -1,6 +1,9 @@
 package config_test
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Huray-hub/eclass-utils/assignments/config"
@@ -26,3 +29,110 @@ func TestImport(t *testing.T) {
 
 	//Assert
 }
+
+func TestIsDocumentIncluded(t *testing.T) {
+	// Arrange
+	opts := &config.Options{
+		DocumentRules: map[string]config.DocumentRules{
+			"*":     {Exclude: []string{"*.mp4", "Βίντεο/*.mov"}},
+			"CS152": {Include: []string{"Διαλέξεις"}, Exclude: []string{"Παλιά"}},
+		},
+	}
+
+	tests := []struct {
+		courseID string
+		path     string
+		expected bool
+	}{
+		{"ICE262", "notes.pdf", true},
+		{"ICE262", "Βίντεο/lecture1.mp4", false},
+		{"ICE262", "Βίντεο/lecture2.mov", false},
+		{"ICE262", "Διαλέξεις/lecture2.mov", true},
+		{"CS152", "Διαλέξεις/lecture1.pdf", true},
+		{"CS152", "Διαλέξεις/Παλιά/lecture0.pdf", false},
+		{"CS152", "Ασκήσεις/set1.pdf", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.courseID+"/"+tt.path, func(t *testing.T) {
+			// Act
+			res := opts.IsDocumentIncluded(tt.courseID, tt.path)
+
+			// Assert
+			if res != tt.expected {
+				t.Errorf("Expected: %v, Actual: %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestSave_KeepsComments(t *testing.T) {
+	// Arrange
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("AppData", home)
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	configPath := filepath.Join(configDir, "eclass-utils", "config.yaml")
+	if err = os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	original := `# my e-class
+credentials:
+    username: student # the student ID
+    password: s3cret
+options:
+    # the platform of my university
+    baseDomain: eclass.uniwa.gr
+    excludedCourses:
+        CS152: {}
+`
+	if err = os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	cfg.Options.BaseDomain = "eclass.aueb.gr"
+	cfg.Options.ManualAssignments = []config.ManualAssignment{
+		{ID: "m1", CourseID: "CS152", Title: "Αναφορά", Deadline: "2022-12-01 23:59"},
+	}
+
+	// Act
+	err = config.Save(cfg)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	saved := string(data)
+	for _, expected := range []string{
+		"# my e-class",
+		"username: student # the student ID",
+		"# the platform of my university\n    baseDomain: eclass.aueb.gr",
+		"title: Αναφορά",
+	} {
+		if !strings.Contains(saved, expected) {
+			t.Errorf("Expected: %v, Actual: %v", expected, saved)
+		}
+	}
+
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, ok := cfg.Options.ExcludedCourses["CS152"]; !ok || len(cfg.Options.ManualAssignments) != 1 {
+		t.Errorf("Expected: %v, Actual: %+v", "the saved options", cfg.Options)
+	}
+}
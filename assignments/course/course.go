@@ -42,3 +42,97 @@ func (crs Course) PrepareAssignmentsURL(baseURL string) (string, error) {
 func extractID(url string) string {
 	return path.Base(url)
 }
+
+func (crs Course) PrepareGradebookURL(baseURL string) (string, error) {
+	finalURL, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	finalURL = finalURL.JoinPath("modules", "gradebook", "index.php")
+
+	values := finalURL.Query()
+	values.Add("course", crs.ID)
+	finalURL.RawQuery = values.Encode()
+
+	return finalURL.String(), nil
+}
+
+func (crs Course) PrepareAnnouncementsURL(baseURL string) (string, error) {
+	finalURL, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	finalURL = finalURL.JoinPath("modules", "announcements", "index.php")
+
+	values := finalURL.Query()
+	values.Add("course", crs.ID)
+	finalURL.RawQuery = values.Encode()
+
+	return finalURL.String(), nil
+}
+
+func (crs Course) PrepareDocumentsURL(baseURL string) (string, error) {
+	finalURL, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	finalURL = finalURL.JoinPath("modules", "document", "index.php")
+
+	values := finalURL.Query()
+	values.Add("course", crs.ID)
+	finalURL.RawQuery = values.Encode()
+
+	return finalURL.String(), nil
+}
+
+func (crs Course) PrepareHomeURL(baseURL string) (string, error) {
+	finalURL, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	finalURL = finalURL.JoinPath("courses", crs.ID+"/")
+
+	return finalURL.String(), nil
+}
+
+func (crs Course) PrepareDescriptionURL(baseURL string) (string, error) {
+	finalURL, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	finalURL = finalURL.JoinPath("modules", "course_description", "index.php")
+
+	values := finalURL.Query()
+	values.Add("course", crs.ID)
+	finalURL.RawQuery = values.Encode()
+
+	return finalURL.String(), nil
+}
+
+func (crs Course) PrepareExercisesURL(baseURL string) (string, error) {
+	finalURL, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	finalURL = finalURL.JoinPath("modules", "exercise", "index.php")
+
+	values := finalURL.Query()
+	values.Add("course", crs.ID)
+	finalURL.RawQuery = values.Encode()
+
+	return finalURL.String(), nil
+}
+
+func (crs Course) PrepareAgendaURL(baseURL string) (string, error) {
+	finalURL, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	finalURL = finalURL.JoinPath("modules", "agenda", "index.php")
+
+	values := finalURL.Query()
+	values.Add("course", crs.ID)
+	finalURL.RawQuery = values.Encode()
+
+	return finalURL.String(), nil
+}
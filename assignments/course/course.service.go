@@ -1,30 +1,26 @@
 package course
 
 import (
+	"context"
+
 	"github.com/Huray-hub/eclass-utils/assignments/config"
 	"github.com/gocolly/colly"
 )
 
-func Get(opts *config.Options, c *colly.Collector) ([]Course, error) {
+// Get fetches the enrolled courses, leaving out the ones excluded by opts.
+func Get(ctx context.Context, opts *config.Options, c *colly.Collector) ([]Course, error) {
 	courses := make([]Course, 0, 10)
 
-	isExcluded := func(course Course) bool {
-		if _, ok := opts.ExcludedCourses[course.ID]; ok {
-			return true
-		}
-		return false
-	}
-
 	c.OnHTML("#main-content table.table-default tbody tr a",
 		func(h *colly.HTMLElement) {
 			if len(h.Text) > 0 {
 				course := newCourse(h.Text, h.Attr("href"))
 
-				if isExcluded(course) {
+				if opts.IsCourseExcluded(course.ID) {
 					return
 				}
 
-				courses = append(courses, newCourse(h.Text, h.Attr("href")))
+				courses = append(courses, course)
 			}
 		})
 
@@ -33,5 +29,9 @@ func Get(opts *config.Options, c *colly.Collector) ([]Course, error) {
 		return nil, err
 	}
 
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	return courses, nil
 }
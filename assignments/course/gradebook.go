@@ -0,0 +1,100 @@
+package course
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/gocolly/colly"
+)
+
+// Gradebook is the student's view of the gradebook of a course.
+type Gradebook struct {
+	Activities []Activity
+	// Total is the weighted total of the gradebook, if shown.
+	Total string
+}
+
+// Activity is a graded activity of a gradebook, ex. an assignment or an
+// exam.
+type Activity struct {
+	Title  string
+	Grade  string
+	Weight string
+}
+
+// GetGradebook fetches the gradebook of crs. Courses without a gradebook
+// have an empty one.
+func GetGradebook(
+	ctx context.Context,
+	opts *config.Options,
+	crs Course,
+	c *colly.Collector,
+) (*Gradebook, error) {
+	gradebook := &Gradebook{}
+	found := false
+
+	c.OnHTML("#main-content table", func(h *colly.HTMLElement) {
+		if found {
+			return
+		}
+
+		grade, weight := -1, -1
+		h.ForEach("tr th", func(i int, th *colly.HTMLElement) {
+			header := strings.ToLower(th.Text)
+			switch {
+			case strings.Contains(header, "βαθμ"):
+				grade = i
+			case strings.Contains(header, "βαρ") || strings.Contains(header, "συντελεστ"):
+				weight = i
+			}
+		})
+		if grade < 0 {
+			return
+		}
+		found = true
+
+		h.ForEach("tr", func(_ int, tr *colly.HTMLElement) {
+			cells := tr.DOM.Find("td")
+			if cells.Length() <= grade {
+				return
+			}
+
+			title := strings.TrimSpace(cells.First().Text())
+			value := strings.TrimSpace(cells.Eq(grade).Text())
+			if isTotal(title) {
+				gradebook.Total = value
+				return
+			}
+
+			activity := Activity{Title: title, Grade: value}
+			if weight >= 0 {
+				activity.Weight = strings.TrimSpace(cells.Eq(weight).Text())
+			}
+			gradebook.Activities = append(gradebook.Activities, activity)
+		})
+	})
+
+	finalURL, err := crs.PrepareGradebookURL(opts.BaseDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.Visit("https://" + finalURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return gradebook, nil
+}
+
+// isTotal reports whether the row titled title is the total of the
+// gradebook, written with or without accents.
+func isTotal(title string) bool {
+	title = strings.ToLower(title)
+	return strings.Contains(title, "σύνολο") || strings.Contains(title, "συνολο")
+}
@@ -0,0 +1,65 @@
+package course
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/internal/testserver"
+	"github.com/gocolly/colly"
+)
+
+const gradebookPage = `<html><body><div id="main-content">
+<table class="table-default">
+	<tr><th>Τίτλος</th><th>Τύπος</th><th>Βαρύτητα</th><th>Βαθμός</th></tr>
+	<tr><td>Άσκηση 1</td><td>Εργασία</td><td>20%</td><td>8,5</td></tr>
+	<tr><td>Τελική εξέταση</td><td>Εξέταση</td><td>80%</td><td>7</td></tr>
+	<tr><td>ΣΥΝΟΛΟ</td><td></td><td></td><td>7,3</td></tr>
+</table>
+</div></body></html>`
+
+func TestGetGradebook(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/modules/gradebook/index.php" || r.URL.Query().Get("course") != "ICE262" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, gradebookPage)
+		},
+	))
+	defer server.Close()
+
+	c := colly.NewCollector()
+	c.WithTransport(testserver.Transport(server))
+
+	opts := &config.Options{BaseDomain: "example.com"}
+
+	// Act
+	gradebook, err := GetGradebook(context.Background(), opts, Course{ID: "ICE262"}, c)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := []Activity{
+		{Title: "Άσκηση 1", Grade: "8,5", Weight: "20%"},
+		{Title: "Τελική εξέταση", Grade: "7", Weight: "80%"},
+	}
+	if len(gradebook.Activities) != len(expected) {
+		t.Fatalf("Expected: %v, Actual: %v", expected, gradebook.Activities)
+	}
+	for i := range expected {
+		if gradebook.Activities[i] != expected[i] {
+			t.Errorf("Expected: %v, Actual: %v", expected[i], gradebook.Activities[i])
+		}
+	}
+	if gradebook.Total != "7,3" {
+		t.Errorf("Expected: %v, Actual: %v", "7,3", gradebook.Total)
+	}
+}
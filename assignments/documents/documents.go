@@ -0,0 +1,220 @@
+// Package documents walks the document trees of the courses, where the
+// professors publish their lecture notes.
+package documents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// Folder is a folder of the documents of a course.
+type Folder struct {
+	Name string
+	// Path is slash separated, relative to the root folder of the course,
+	// which has an empty one.
+	Path    string
+	Folders []Folder
+	Files   []File
+}
+
+// File is a document of a course.
+type File struct {
+	Name string
+	// Path is slash separated, relative to the root folder of the course.
+	Path string
+	// Size is as e-class shows it, ex. 1.2 MB.
+	Size string
+	Date time.Time
+	URL  string
+}
+
+// Version identifies the version of the file by its size and date. It is
+// empty when the date has no time of day, as e-class mostly shows it: the
+// size is rounded, so two versions of the same day would look the same.
+// Downloads compare the ETag and the exact size of the file instead then.
+func (f File) Version() string {
+	if !hasTime(f.Date) {
+		return ""
+	}
+	return f.Size + " " + f.Date.Format(time.RFC3339)
+}
+
+// hasTime reports whether the date has a time of day, as dates listed
+// without one are parsed as midnight.
+func hasTime(date time.Time) bool {
+	hour, minute, second := date.Clock()
+	return !date.IsZero() && hour+minute+second > 0
+}
+
+// AllFiles returns the files of the folder and of its subfolders.
+func (f *Folder) AllFiles() []File {
+	files := append([]File{}, f.Files...)
+	for i := range f.Folders {
+		files = append(files, f.Folders[i].AllFiles()...)
+	}
+	return files
+}
+
+// Get walks the document tree of crs, with c logged in. A course without
+// the documents module has an empty one.
+func Get(
+	ctx context.Context,
+	opts *config.Options,
+	crs course.Course,
+	c *colly.Collector,
+) (*Folder, error) {
+	rootURL, err := crs.PrepareDocumentsURL(opts.BaseDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &Folder{}
+	visited := make(map[string]struct{})
+
+	err = walk(ctx, "https://"+rootURL, root, visited, c)
+	if errors.Is(err, errNoModule) {
+		log.Println("no documents for course", crs.ID+":", err.Error())
+		return &Folder{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// errNoModule is returned by walk when the root folder is missing, as the
+// documents module is disabled in the course.
+var errNoModule = errors.New("documents module disabled")
+
+// walk fills in folder with the listing at folderURL and walks its
+// subfolders.
+func walk(
+	ctx context.Context,
+	folderURL string,
+	folder *Folder,
+	visited map[string]struct{},
+	c *colly.Collector,
+) error {
+	if _, ok := visited[folderURL]; ok {
+		return nil
+	}
+	visited[folderURL] = struct{}{}
+
+	subfolderURLs := make([]string, 0, 4)
+
+	listing := c.Clone()
+	listing.OnHTML("#main-content table tr", func(h *colly.HTMLElement) {
+		link := h.DOM.Find("a[href*='openDir='], a[href*='file.php']").First()
+		href, ok := link.Attr("href")
+		name := strings.TrimSpace(link.Text())
+		if !ok || name == "" {
+			return
+		}
+
+		if strings.Contains(href, "openDir=") {
+			folder.Folders = append(folder.Folders, Folder{
+				Name: name,
+				Path: join(folder.Path, name),
+			})
+			subfolderURLs = append(subfolderURLs, h.Request.AbsoluteURL(href))
+			return
+		}
+
+		file := File{
+			Name: name,
+			Path: join(folder.Path, name),
+			URL:  h.Request.AbsoluteURL(href),
+		}
+		parseColumns(h.DOM.Find("td"), &file)
+		folder.Files = append(folder.Files, file)
+	})
+
+	// e-class answers with 403 or 404 for the modules that are disabled in
+	// the course
+	var status int
+	listing.OnError(func(r *colly.Response, _ error) {
+		status = r.StatusCode
+	})
+
+	err := listing.Visit(folderURL)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if folder.Path == "" && (status == http.StatusNotFound || status == http.StatusForbidden) {
+			return fmt.Errorf("%w: %v", errNoModule, err)
+		}
+		return err
+	}
+
+	for i, subfolderURL := range subfolderURLs {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if !isSubfolder(folderURL, subfolderURL) {
+			continue
+		}
+
+		err = walk(ctx, subfolderURL, &folder.Folders[i], visited, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+var sizePattern = regexp.MustCompile(`^\d+([.,]\d+)?\s*(B|KB|MB|GB|bytes)$`)
+
+// parseColumns finds the size and the date of a file among the columns of
+// its row, which differ between versions of e-class.
+func parseColumns(tds *goquery.Selection, file *File) {
+	tds.Each(func(_ int, td *goquery.Selection) {
+		text := strings.TrimSpace(td.Text())
+		switch {
+		case sizePattern.MatchString(text):
+			file.Size = text
+		case file.Date.IsZero():
+			if date, err := assignment.ParseDate(text); err == nil {
+				file.Date = date
+			}
+		}
+	})
+}
+
+// isSubfolder reports whether the openDir of subfolderURL is below the
+// one of folderURL, so that links to parent folders are not walked.
+func isSubfolder(folderURL, subfolderURL string) bool {
+	parent, err := url.Parse(folderURL)
+	if err != nil {
+		return false
+	}
+	child, err := url.Parse(subfolderURL)
+	if err != nil {
+		return false
+	}
+
+	parentDir := parent.Query().Get("openDir")
+	childDir := child.Query().Get("openDir")
+	return childDir != "" && strings.HasPrefix(childDir, parentDir) && childDir != parentDir
+}
+
+func join(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
@@ -0,0 +1,171 @@
+package documents
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/internal/testserver"
+	"github.com/gocolly/colly"
+)
+
+const rootPage = `<html><body><div id="main-content">
+<table class="table-default">
+	<tr><th>Τύπος</th><th>Όνομα</th><th>Μέγεθος</th><th>Ημερομηνία</th></tr>
+	<tr>
+		<td><i class="fa fa-folder"></i></td>
+		<td><a href="index.php?course=CS152&openDir=/5f1a">Διαλέξεις</a></td>
+		<td></td>
+		<td>01-10-2022</td>
+	</tr>
+	<tr>
+		<td><i class="fa fa-file"></i></td>
+		<td><a href="file.php/CS152/syllabus.pdf">syllabus.pdf</a></td>
+		<td>120 KB</td>
+		<td>30-09-2022</td>
+	</tr>
+</table>
+</div></body></html>`
+
+const folderPage = `<html><body><div id="main-content">
+<table class="table-default">
+	<tr>
+		<td></td>
+		<td><a href="index.php?course=CS152&openDir=">Επάνω</a></td>
+	</tr>
+	<tr>
+		<td><i class="fa fa-file"></i></td>
+		<td><a href="file.php/CS152/5f1a/lecture1.pdf">lecture1.pdf</a></td>
+		<td>1.2 MB</td>
+		<td>03-10-2022</td>
+	</tr>
+</table>
+</div></body></html>`
+
+func TestGet(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("openDir") == "/5f1a" {
+				fmt.Fprint(w, folderPage)
+				return
+			}
+			fmt.Fprint(w, rootPage)
+		},
+	))
+	defer server.Close()
+
+	c := colly.NewCollector()
+	c.WithTransport(testserver.Transport(server))
+
+	opts := &config.Options{BaseDomain: "example.com"}
+
+	// Act
+	root, err := Get(context.Background(), opts, course.Course{ID: "CS152"}, c)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	files := root.AllFiles()
+	expected := []File{
+		{Name: "syllabus.pdf", Path: "syllabus.pdf", Size: "120 KB"},
+		{Name: "lecture1.pdf", Path: "Διαλέξεις/lecture1.pdf", Size: "1.2 MB"},
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("Expected: %v, Actual: %v", expected, files)
+	}
+	for i := range expected {
+		if files[i].Path != expected[i].Path || files[i].Size != expected[i].Size {
+			t.Errorf("Expected: %v, Actual: %v", expected[i], files[i])
+		}
+		if files[i].Date.IsZero() {
+			t.Errorf("Expected the date of %v", files[i].Path)
+		}
+	}
+
+	expectedURL := "https://example.com/modules/document/file.php/CS152/5f1a/lecture1.pdf"
+	if files[1].URL != expectedURL {
+		t.Errorf("Expected: %v, Actual: %v", expectedURL, files[1].URL)
+	}
+}
+
+func TestGet_NoDocumentsModule(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		fails  bool
+	}{
+		{name: "not found", status: http.StatusNotFound},
+		{name: "forbidden", status: http.StatusForbidden},
+		{name: "server error", status: http.StatusServiceUnavailable, fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewTLSServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(tt.status)
+				},
+			))
+			defer server.Close()
+
+			c := colly.NewCollector()
+			c.WithTransport(testserver.Transport(server))
+
+			opts := &config.Options{BaseDomain: "example.com"}
+
+			// Act
+			root, err := Get(context.Background(), opts, course.Course{ID: "CS152"}, c)
+
+			// Assert
+			if (err != nil) != tt.fails {
+				t.Fatalf("Expected failure: %v, Actual: %v", tt.fails, err)
+			}
+			if !tt.fails && len(root.AllFiles()) != 0 {
+				t.Errorf("Expected: %v, Actual: %v", 0, root.AllFiles())
+			}
+		})
+	}
+}
+
+func TestFileVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     File
+		expected string
+	}{
+		{
+			name:     "date and time",
+			file:     File{Size: "1.2 MB", Date: time.Date(2022, 10, 3, 14, 5, 0, 0, time.UTC)},
+			expected: "1.2 MB 2022-10-03T14:05:00Z",
+		},
+		{
+			name: "date only",
+			file: File{Size: "1.2 MB", Date: time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "size only",
+			file: File{Size: "1.2 MB"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			actual := tt.file.Version()
+
+			// Assert
+			if actual != tt.expected {
+				t.Errorf("Expected: %q, Actual: %q", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+// Package download mirrors files of e-class into a local directory, such
+// as the files of assignments into a tree organised as
+// <course>/<assignment title> (<assignment ID>)/.
+package download
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+)
+
+// submittedDir is the directory under an assignment's that keeps the
+// submitted files apart from the professor's attachments.
+const submittedDir = "submitted"
+
+// Filter picks the assignments to download. Empty sets pick everything.
+type Filter struct {
+	CourseIDs     map[string]struct{}
+	AssignmentIDs map[string]struct{}
+}
+
+// Includes reports whether the files of a are picked by f.
+func (f Filter) Includes(a assignment.Assignment) bool {
+	if len(f.CourseIDs) > 0 {
+		if _, ok := f.CourseIDs[a.Course.ID]; !ok {
+			return false
+		}
+	}
+	if len(f.AssignmentIDs) > 0 {
+		if _, ok := f.AssignmentIDs[a.ID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Report lists the files of a download, as slash separated paths relative
+// to its directory.
+type Report struct {
+	// New are the files downloaded for the first time.
+	New []string
+	// Changed are the files downloaded again because they changed.
+	Changed []string
+	// Skipped are the files that did not change since the last download.
+	Skipped []string
+}
+
+// File is a file to download.
+type File struct {
+	// Path is slash separated and relative to the download directory, see
+	// Join.
+	Path string
+	URL  string
+	// Version identifies the version of the file when it is known without
+	// requesting the file, ex. the size and the date of a listing. Files
+	// without one are compared by the size and the ETag of a HEAD request.
+	Version string
+}
+
+// Join joins the names into a path for File, replacing the characters
+// that are not allowed in file names.
+func Join(names ...string) string {
+	safe := make([]string, 0, len(names))
+	for _, name := range names {
+		safe = append(safe, sanitize(name))
+	}
+	return strings.Join(safe, "/")
+}
+
+// Mirror downloads the attachments and the submitted files of the
+// assignments picked by filter into dir, with client logged in. The assignments
+// need their details, see assignment.FetchDetails. Files whose size and
+// ETag are the same as in the last download are skipped.
+func Mirror(
+	ctx context.Context,
+	dir string,
+	assignments []assignment.Assignment,
+	filter Filter,
+	client *http.Client,
+) (*Report, error) {
+	files := make([]File, 0, len(assignments))
+	for _, a := range assignments {
+		if a.Manual || !filter.Includes(a) {
+			continue
+		}
+
+		for _, file := range a.Attachments {
+			files = append(files, File{
+				Path: Join(a.Course.ID, assignmentDir(a), file.Name),
+				URL:  file.URL,
+			})
+		}
+		for _, file := range a.SubmittedFiles {
+			files = append(files, File{
+				Path: Join(a.Course.ID, assignmentDir(a), submittedDir, file.Name),
+				URL:  file.URL,
+			})
+		}
+	}
+
+	return Files(ctx, dir, files, client)
+}
+
+// assignmentDir names the directory of the files of a by its title and its
+// ID, as assignments of the same course may share a title.
+func assignmentDir(a assignment.Assignment) string {
+	return fmt.Sprintf("%v (%v)", a.Title, a.ID)
+}
+
+// Files downloads the files into dir, with client logged in, skipping the
+// ones that did not change since the last download into dir.
+func Files(ctx context.Context, dir string, files []File, client *http.Client) (*Report, error) {
+	manifest, err := loadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	report := &Report{}
+	for _, file := range files {
+		_, existed := manifest[file.Path]
+
+		downloaded, err := mirrorFile(ctx, dir, file, manifest, client)
+		if err != nil {
+			// keep what was downloaded so far
+			if saveErr := manifest.save(dir); saveErr != nil {
+				return report, saveErr
+			}
+			return report, fmt.Errorf("%v: %w", file.Path, err)
+		}
+
+		switch {
+		case !downloaded:
+			report.Skipped = append(report.Skipped, file.Path)
+		case existed:
+			report.Changed = append(report.Changed, file.Path)
+		default:
+			report.New = append(report.New, file.Path)
+		}
+	}
+
+	return report, manifest.save(dir)
+}
+
+// mirrorFile downloads the file into dir, unless it is unchanged since
+// the last download.
+func mirrorFile(
+	ctx context.Context,
+	dir string,
+	file File,
+	manifest manifest,
+	client *http.Client,
+) (bool, error) {
+	current := entry{URL: file.URL, Version: file.Version}
+	if file.Version == "" {
+		var err error
+		current, err = head(ctx, file.URL, client)
+		if err != nil {
+			return false, err
+		}
+		current.URL = file.URL
+	}
+
+	fullPath := filepath.Join(dir, filepath.FromSlash(file.Path))
+	if manifest[file.Path].matches(current) && exists(fullPath, current.Size) {
+		return false, nil
+	}
+
+	err := os.MkdirAll(filepath.Dir(fullPath), 0755)
+	if err != nil {
+		return false, err
+	}
+
+	err = get(ctx, file.URL, fullPath, client)
+	if err != nil {
+		return false, err
+	}
+
+	manifest[file.Path] = current
+	return true, nil
+}
+
+// head requests the size and the ETag of the file at fileURL.
+func head(ctx context.Context, fileURL string, client *http.Client) (entry, error) {
+	resp, err := do(ctx, http.MethodHead, fileURL, client)
+	if err != nil {
+		return entry{}, err
+	}
+	resp.Body.Close()
+
+	e := entry{ETag: resp.Header.Get("ETag")}
+	// the size is -1 when unknown
+	if resp.ContentLength > 0 {
+		e.Size = resp.ContentLength
+	}
+	return e, nil
+}
+
+// get saves the file at fileURL to path as it is received, through a
+// temporary file so that a failed download leaves the previous one in
+// place.
+func get(ctx context.Context, fileURL, path string, client *http.Client) error {
+	resp, err := do(ctx, http.MethodGet, fileURL, client)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	tmp := path + ".part"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// do sends the request and fails on the responses that are not 2xx.
+func do(ctx context.Context, method, fileURL string, client *http.Client) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%v %v: %v", method, fileURL, resp.Status)
+	}
+	return resp, nil
+}
+
+func exists(path string, size int64) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return size <= 0 || info.Size() == size
+}
+
+// sanitize makes name safe to use as a file or directory name on every
+// platform.
+func sanitize(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 32 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+
+	name = strings.Trim(strings.TrimSpace(name), ".")
+	if name == "" {
+		return "_"
+	}
+	return name
+}
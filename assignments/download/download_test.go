@@ -0,0 +1,150 @@
+package download
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/internal/testserver"
+)
+
+func TestMirror(t *testing.T) {
+	// Arrange
+	etag := `"v1"`
+	gets := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				gets++
+			}
+			w.Header().Set("ETag", etag)
+			_, _ = w.Write([]byte("content " + etag))
+		},
+	))
+	defer server.Close()
+
+	client := &http.Client{Transport: testserver.Transport(server)}
+
+	dir := t.TempDir()
+	assignments := []assignment.Assignment{
+		{
+			ID:     "24692",
+			Course: &course.Course{ID: "ICE262"},
+			Title:  "Άσκηση 1: BM25",
+			Attachments: []assignment.Attachment{
+				{Name: "ekfonisi.pdf", URL: "https://example.com/modules/work/index.php?get=1"},
+			},
+			SubmittedFiles: []assignment.Attachment{
+				{Name: "lysi.zip", URL: "https://example.com/modules/work/index.php?get=2"},
+			},
+		},
+		{
+			ID:     "100",
+			Course: &course.Course{ID: "CS152"},
+			Title:  "Άσκηση 2",
+			Attachments: []assignment.Attachment{
+				{Name: "other.pdf", URL: "https://example.com/modules/work/index.php?get=3"},
+			},
+		},
+	}
+	filter := Filter{CourseIDs: map[string]struct{}{"ICE262": {}}}
+
+	tests := []struct {
+		name            string
+		etag            string
+		expectedNew     int
+		expectedChanged int
+		expectedSkipped int
+		expectedGets    int
+	}{
+		{"first run", `"v1"`, 2, 0, 0, 2},
+		{"unchanged", `"v1"`, 0, 0, 2, 2},
+		{"changed", `"v2"`, 0, 2, 0, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			etag = tt.etag
+
+			// Act
+			report, err := Mirror(context.Background(), dir, assignments, filter, client)
+
+			// Assert
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if len(report.New) != tt.expectedNew {
+				t.Errorf("Expected: %v, Actual: %v", tt.expectedNew, report.New)
+			}
+			if len(report.Changed) != tt.expectedChanged {
+				t.Errorf("Expected: %v, Actual: %v", tt.expectedChanged, report.Changed)
+			}
+			if len(report.Skipped) != tt.expectedSkipped {
+				t.Errorf("Expected: %v, Actual: %v", tt.expectedSkipped, report.Skipped)
+			}
+			if gets != tt.expectedGets {
+				t.Errorf("Expected: %v, Actual: %v", tt.expectedGets, gets)
+			}
+		})
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "ICE262", "Άσκηση 1_ BM25 (24692)", "submitted", "lysi.zip"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if string(data) != `content "v2"` {
+		t.Errorf("Expected: %v, Actual: %v", `content "v2"`, string(data))
+	}
+	if _, err = os.Stat(filepath.Join(dir, "CS152")); err == nil {
+		t.Errorf("Expected CS152 to be filtered out")
+	}
+}
+
+func TestFiles_FailedDownload(t *testing.T) {
+	// Arrange
+	status := http.StatusOK
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte("content"))
+		},
+	))
+	defer server.Close()
+
+	client := &http.Client{Transport: testserver.Transport(server)}
+
+	dir := t.TempDir()
+	files := []File{{
+		Path:    "ICE262/ekfonisi.pdf",
+		URL:     "https://example.com/modules/document/file.php/ICE262/ekfonisi.pdf",
+		Version: "1",
+	}}
+	if _, err := Files(context.Background(), dir, files, client); err != nil {
+		t.Fatal(err.Error())
+	}
+	status = http.StatusInternalServerError
+	files[0].Version = "2"
+
+	// Act
+	_, err := Files(context.Background(), dir, files, client)
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected an error, Actual: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "ICE262", "ekfonisi.pdf"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if string(data) != "content" {
+		t.Errorf("Expected: %v, Actual: %v", "content", string(data))
+	}
+	if _, err = os.Stat(filepath.Join(dir, "ICE262", "ekfonisi.pdf.part")); err == nil {
+		t.Errorf("Expected no temporary file")
+	}
+}
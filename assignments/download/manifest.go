@@ -0,0 +1,63 @@
+package download
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// manifestName is the file in the download directory that remembers what
+// was downloaded.
+const manifestName = ".eclass-manifest.json"
+
+// manifest maps the path of every downloaded file, relative to the
+// download directory, to the version of it that was downloaded.
+type manifest map[string]entry
+
+type entry struct {
+	URL     string `json:"url"`
+	Size    int64  `json:"size,omitempty"`
+	ETag    string `json:"etag,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// matches reports whether e and current are the same version of a file.
+// Without a version, a size or an ETag there is nothing to compare, so the
+// file is assumed to have changed.
+func (e entry) matches(current entry) bool {
+	if current.Version == "" && current.Size <= 0 && current.ETag == "" {
+		return false
+	}
+	return e == current
+}
+
+func loadManifest(dir string) (manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestName))
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m := manifest{}
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m manifest) save(dir string) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, manifestName), data, 0644)
+}
@@ -0,0 +1,206 @@
+package eclass
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/announcement"
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/documents"
+	"github.com/Huray-hub/eclass-utils/assignments/download"
+	"github.com/Huray-hub/eclass-utils/assignments/event"
+	"github.com/Huray-hub/eclass-utils/assignments/login"
+	"github.com/Huray-hub/eclass-utils/assignments/professor"
+	"github.com/Huray-hub/eclass-utils/assignments/session"
+	"github.com/Huray-hub/eclass-utils/assignments/submission"
+)
+
+// Client is a logged in user of an e-class platform. Every method honors
+// the deadline and the cancellation of its context, including requests in
+// flight. A Client is safe for concurrent use once logged in.
+type Client struct {
+	opts        config.Options
+	creds       config.Credentials
+	session     *session.Session
+	sessionPath string
+}
+
+// Option configures a Client.
+type Option func(*clientConfig)
+
+type clientConfig struct {
+	opts        config.Options
+	transport   http.RoundTripper
+	sessionPath string
+}
+
+// WithOptions sets the options used to exclude courses and assignments and
+// to limit the requests. Its BaseDomain is ignored.
+func WithOptions(opts config.Options) Option {
+	return func(cfg *clientConfig) {
+		cfg.opts = opts
+	}
+}
+
+// WithTransport sets the transport used for the requests.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(cfg *clientConfig) {
+		cfg.transport = transport
+	}
+}
+
+// WithSessionFile keeps the session cookies in the file at path, so that
+// later clients skip logging in while the session is alive.
+func WithSessionFile(path string) Option {
+	return func(cfg *clientConfig) {
+		cfg.sessionPath = path
+	}
+}
+
+// NewClient creates a client for the e-class platform at baseDomain
+// (ex. eclass.uniwa.gr). It has to Login before fetching anything.
+func NewClient(baseDomain string, creds config.Credentials, options ...Option) *Client {
+	var cfg clientConfig
+	for _, option := range options {
+		option(&cfg)
+	}
+	cfg.opts.BaseDomain = baseDomain
+
+	return &Client{
+		opts:        cfg.opts,
+		creds:       creds,
+		session:     session.New(baseDomain, cfg.transport),
+		sessionPath: cfg.sessionPath,
+	}
+}
+
+// Login logs in with the client's credentials. With a session file, the
+// saved session is reused while it is alive. A session file that cannot be
+// read is discarded.
+func (c *Client) Login(ctx context.Context) error {
+	if c.sessionPath == "" {
+		return login.Login(ctx, c.opts.BaseDomain, c.creds, c.session.Collector(ctx))
+	}
+
+	return login.ResumeSession(ctx, c.session, c.sessionPath, c.creds)
+}
+
+// Courses fetches the enrolled courses.
+func (c *Client) Courses(ctx context.Context) ([]course.Course, error) {
+	return course.Get(ctx, &c.opts, c.session.Collector(ctx))
+}
+
+// Assignments fetches the assignments of a single course.
+func (c *Client) Assignments(
+	ctx context.Context,
+	crs course.Course,
+) ([]assignment.Assignment, error) {
+	return assignment.FetchCourse(ctx, &c.opts, crs, c.session.Collector(ctx))
+}
+
+// AllAssignments fetches the assignments of every enrolled course, sorted
+// by deadline. When only some of the courses fail, the assignments of the
+// rest are returned along with an *assignment.PartialError.
+func (c *Client) AllAssignments(ctx context.Context) ([]assignment.Assignment, error) {
+	courses, err := c.Courses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.CoursesAssignments(ctx, courses)
+}
+
+// CoursesAssignments fetches the assignments of the given courses
+// concurrently, sorted by deadline, like AllAssignments.
+func (c *Client) CoursesAssignments(
+	ctx context.Context,
+	courses []course.Course,
+) ([]assignment.Assignment, error) {
+	return assignment.FetchCourses(ctx, &c.opts, courses, c.session.Collector(ctx))
+}
+
+// Download mirrors the attachments and the submitted files of the
+// assignments picked by filter into dir. The assignments need their
+// details, see config.Options.FetchDetails.
+func (c *Client) Download(
+	ctx context.Context,
+	dir string,
+	assignments []assignment.Assignment,
+	filter download.Filter,
+) (*download.Report, error) {
+	return download.Mirror(ctx, dir, assignments, filter, c.session.Client(ctx))
+}
+
+// SubmissionForm reads the submission form of the page of a.
+func (c *Client) SubmissionForm(
+	ctx context.Context,
+	a assignment.Assignment,
+) (*submission.Form, error) {
+	return submission.GetForm(ctx, &c.opts, &a, c.session.Collector(ctx))
+}
+
+// Submit uploads the files at paths through form, the submission form of
+// a, and confirms that a is marked as submitted afterwards.
+func (c *Client) Submit(
+	ctx context.Context,
+	a assignment.Assignment,
+	form *submission.Form,
+	paths []string,
+) error {
+	return submission.Submit(ctx, &c.opts, &a, form, paths, c.session.Collector(ctx))
+}
+
+// Gradebook fetches the gradebook of a single course.
+func (c *Client) Gradebook(ctx context.Context, crs course.Course) (*course.Gradebook, error) {
+	return course.GetGradebook(ctx, &c.opts, crs, c.session.Collector(ctx))
+}
+
+// Announcements fetches the announcements of the given courses, newest
+// first. Only the ones that are not read in state have their body and
+// attachments; see announcement.Get.
+func (c *Client) Announcements(
+	ctx context.Context,
+	courses []course.Course,
+	state *announcement.State,
+) ([]announcement.Announcement, error) {
+	return announcement.Get(ctx, &c.opts, courses, state, c.session.Collector(ctx))
+}
+
+// Events fetches the agenda events of the given courses and the personal
+// events of the portfolio that start between from and to, sorted by start.
+func (c *Client) Events(
+	ctx context.Context,
+	courses []course.Course,
+	from time.Time,
+	to time.Time,
+) ([]event.Event, error) {
+	return event.Get(ctx, &c.opts, courses, from, to, c.session.Collector(ctx))
+}
+
+// Documents walks the document tree of a single course.
+func (c *Client) Documents(ctx context.Context, crs course.Course) (*documents.Folder, error) {
+	return documents.Get(ctx, &c.opts, crs, c.session.Collector(ctx))
+}
+
+// DownloadFiles downloads the files into dir, skipping the ones that did
+// not change since the last download into dir.
+func (c *Client) DownloadFiles(
+	ctx context.Context,
+	dir string,
+	files []download.File,
+) (*download.Report, error) {
+	return download.Files(ctx, dir, files, c.session.Client(ctx))
+}
+
+// Professors fetches the contact information of the instructors of the
+// given courses, deduplicated across them. When only some of the courses
+// fail, the professors of the rest are returned along with an error.
+func (c *Client) Professors(
+	ctx context.Context,
+	courses []course.Course,
+) ([]professor.Professor, error) {
+	return professor.Get(ctx, &c.opts, courses, c.session.Collector(ctx))
+}
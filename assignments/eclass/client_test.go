@@ -0,0 +1,116 @@
+package eclass_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/eclass"
+	"github.com/Huray-hub/eclass-utils/assignments/internal/testserver"
+)
+
+func TestClientCourses(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `<div id="main-content"><table class="table-default"><tbody>
+<tr><td><a href="https://example.com/courses/CS152">Αλγόριθμοι</a></td></tr>
+<tr><td><a href="https://example.com/courses/ICE262">Ανάκτηση Πληροφορίας</a></td></tr>
+</tbody></table></div>`)
+		},
+	))
+	defer server.Close()
+
+	client := eclass.NewClient(
+		"example.com",
+		config.Credentials{},
+		eclass.WithOptions(config.Options{
+			ExcludedCourses: map[string]struct{}{"ICE262": {}},
+		}),
+		eclass.WithTransport(testserver.Transport(server)),
+	)
+
+	// Act
+	courses, err := client.Courses(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(courses) != 1 || courses[0].ID != "CS152" {
+		t.Errorf("Expected only CS152, Actual: %v", courses)
+	}
+}
+
+func TestClientCourses_DeadlineExceeded(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		},
+	))
+	defer server.Close()
+
+	client := eclass.NewClient(
+		"example.com",
+		config.Credentials{},
+		eclass.WithTransport(testserver.Transport(server)),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := client.Courses(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected: %v, Actual: %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestClientLogin_CorruptSessionFile(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			http.SetCookie(w, &http.Cookie{Name: "PHPSESSID", Value: "s3cr3t"})
+			fmt.Fprint(w, `<html><body><div id="main-content"></div></body></html>`)
+		},
+	))
+	defer server.Close()
+
+	sessionPath := filepath.Join(t.TempDir(), "session.json")
+	if err := os.WriteFile(sessionPath, []byte("{"), 0600); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	client := eclass.NewClient(
+		"example.com",
+		config.Credentials{Username: "user"},
+		eclass.WithTransport(testserver.Transport(server)),
+		eclass.WithSessionFile(sessionPath),
+	)
+
+	// Act
+	err := client.Login(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	data, err := os.ReadFile(sessionPath)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !json.Valid(data) {
+		t.Errorf("Expected: %v, Actual: %s", "a session file saved again", data)
+	}
+}
@@ -0,0 +1,277 @@
+// Package event fetches the events of the agenda of the enrolled courses,
+// like exams, lab sessions and online lectures, and of the personal
+// calendar of the portfolio.
+package event
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/text"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// durationPattern matches the duration of an agenda event, as hours and
+// minutes.
+var durationPattern = regexp.MustCompile(`(\d+):(\d{2})`)
+
+// Event is an entry of the agenda of a course or of the personal
+// calendar.
+type Event struct {
+	ID string
+	// Course is nil for the personal events of the portfolio.
+	Course *course.Course
+	Title  string
+	Start  time.Time
+	// End is zero for the events without a duration.
+	End     time.Time
+	Content string
+	URL     string
+}
+
+// Key identifies the event across courses and the personal calendar.
+func (e *Event) Key() string {
+	if e.Course == nil {
+		return "personal/" + e.ID
+	}
+	return e.Course.ID + "/" + e.ID
+}
+
+// Get fetches the agenda events of the courses and the personal events of
+// the portfolio that start between from and to, sorted by start. Course
+// events that the personal calendar repeats are only returned once. When
+// only some of the calendars fail, the events of the rest are returned
+// along with an error listing the failures.
+func Get(
+	ctx context.Context,
+	opts *config.Options,
+	courses []course.Course,
+	from time.Time,
+	to time.Time,
+	c *colly.Collector,
+) ([]Event, error) {
+	err := c.Limit(&colly.LimitRule{DomainGlob: "*", Delay: opts.RequestDelay})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, 10)
+	seen := make(map[string]struct{})
+	add := func(fetched []Event) {
+		for _, e := range fetched {
+			if _, ok := seen[e.Key()]; ok || e.Start.Before(from) || e.Start.After(to) {
+				continue
+			}
+			seen[e.Key()] = struct{}{}
+			events = append(events, e)
+		}
+	}
+
+	var failures []string
+
+	for i := range courses {
+		epc, err := FetchCourse(ctx, opts, &courses[i], c.Clone())
+		if err = ctxErr(ctx, err); err != nil {
+			failures = append(failures, fmt.Sprintf("course %v: %v", courses[i].ID, err))
+			continue
+		}
+		add(epc)
+	}
+
+	personal, err := FetchPersonal(ctx, opts, courses, from, to, c.Clone())
+	if err = ctxErr(ctx, err); err != nil {
+		failures = append(failures, fmt.Sprintf("personal calendar: %v", err))
+	}
+	add(personal)
+
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+
+	if len(failures) > 0 {
+		err = fmt.Errorf(
+			"failed to fetch events of %v calendar(s):\n%v",
+			len(failures),
+			strings.Join(failures, "\n"),
+		)
+		if len(failures) == len(courses)+1 {
+			return nil, err
+		}
+	}
+	return events, err
+}
+
+func ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// agendaColumns are the positions of the columns of the agenda table,
+// found by their headers. Missing columns are -1.
+type agendaColumns struct {
+	start    int
+	end      int
+	duration int
+}
+
+// FetchCourse fetches the events of the agenda of a single course. A
+// course without the agenda module has none.
+func FetchCourse(
+	ctx context.Context,
+	opts *config.Options,
+	crs *course.Course,
+	c *colly.Collector,
+) ([]Event, error) {
+	events := make([]Event, 0, 10)
+
+	c.OnHTML("#main-content table", func(h *colly.HTMLElement) {
+		columns := findColumns(h.DOM)
+		if columns.start < 0 {
+			return
+		}
+
+		h.DOM.Find("tr").Each(func(_ int, row *goquery.Selection) {
+			e, ok := newEvent(row, columns, crs, h.Request.AbsoluteURL)
+			if ok {
+				events = append(events, e)
+			}
+		})
+	})
+
+	agendaURL, err := crs.PrepareAgendaURL(opts.BaseDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	// e-class answers with 403 or 404 for the modules that are disabled in
+	// the course, as in many courses
+	var status int
+	c.OnError(func(r *colly.Response, _ error) {
+		status = r.StatusCode
+	})
+
+	err = c.Visit("https://" + agendaURL)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if status == http.StatusNotFound || status == http.StatusForbidden {
+			log.Println("no agenda for course", crs.ID+":", err.Error())
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return events, ctx.Err()
+}
+
+func findColumns(table *goquery.Selection) agendaColumns {
+	columns := agendaColumns{start: -1, end: -1, duration: -1}
+
+	table.Find("th").Each(func(i int, th *goquery.Selection) {
+		header := text.Normalize(th.Text())
+		switch {
+		case strings.Contains(header, text.Normalize("ημερομηνία")),
+			strings.Contains(header, text.Normalize("έναρξη")):
+			columns.start = i
+		case strings.Contains(header, text.Normalize("λήξη")):
+			columns.end = i
+		case strings.Contains(header, text.Normalize("διάρκεια")):
+			columns.duration = i
+		}
+	})
+
+	return columns
+}
+
+// newEvent reads a row of the agenda table. Rows without a link to an
+// event or without a start are skipped.
+func newEvent(
+	row *goquery.Selection,
+	columns agendaColumns,
+	crs *course.Course,
+	absoluteURL func(string) string,
+) (Event, bool) {
+	tds := row.Find("td")
+	link := tds.First().Find("a[href*='id=']").First()
+	href, ok := link.Attr("href")
+	if !ok {
+		return Event{}, false
+	}
+
+	eventURL := absoluteURL(href)
+	id := queryID(eventURL)
+	if id == "" {
+		return Event{}, false
+	}
+
+	start, err := assignment.ParseDate(tds.Eq(columns.start).Text())
+	if err != nil {
+		return Event{}, false
+	}
+
+	e := Event{
+		ID:      id,
+		Course:  crs,
+		Title:   strings.TrimSpace(link.Text()),
+		Start:   start,
+		Content: strings.TrimSpace(tds.First().Find(".table_td_body").Text()),
+		URL:     eventURL,
+	}
+
+	switch {
+	case columns.end >= 0:
+		if end, err := assignment.ParseDate(tds.Eq(columns.end).Text()); err == nil {
+			e.End = end
+		}
+	case columns.duration >= 0:
+		if d := parseDuration(tds.Eq(columns.duration).Text()); d > 0 {
+			e.End = start.Add(d)
+		}
+	}
+
+	return e, true
+}
+
+func parseDuration(text string) time.Duration {
+	match := durationPattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0
+	}
+
+	hours, _ := strconv.Atoi(match[1])
+	minutes, _ := strconv.Atoi(match[2])
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+}
+
+// queryID returns the numeric id parameter of rawURL, or nothing.
+func queryID(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	id := u.Query().Get("id")
+	if _, err := strconv.Atoi(id); err != nil {
+		return ""
+	}
+	return id
+}
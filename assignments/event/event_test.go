@@ -0,0 +1,176 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/internal/testserver"
+	"github.com/gocolly/colly"
+)
+
+const agendaPage = `<html><body>
+<div id="main-content">
+<table class="table-default">
+	<tr class="list-header">
+		<th>Γεγονός</th>
+		<th>Ημερομηνία</th>
+		<th>Διάρκεια</th>
+	</tr>
+	<tr>
+		<td><div class="table_td">
+			<div class="table_td_header clearfix"><a href="index.php?course=%[1]v&amp;id=42">Εξέταση</a></div>
+			<div class="table_td_body" data-id="42">Αμφιθέατρο Α</div>
+		</div></td>
+		<td>15-12-2022 10:00</td>
+		<td>02:00</td>
+	</tr>
+	<tr>
+		<td><div class="table_td">
+			<div class="table_td_header clearfix"><a href="index.php?course=%[1]v&amp;id=43">Εργαστήριο</a></div>
+		</div></td>
+		<td>12-12-2022 16:00</td>
+		<td></td>
+	</tr>
+</table>
+</div>
+</body></html>`
+
+// calendarJSON lists an agenda event already in the agenda of the course,
+// an assignment deadline, an agenda event of a course that is not fetched
+// and a personal event.
+const calendarJSON = `{"success":1,"result":[
+	{"id":"42","title":"Εξέταση","url":"/modules/agenda/index.php?course=ICE262&id=42","start":"1671091200000","end":"1671098400000"},
+	{"id":"24692","title":"Άσκηση 1","url":"/modules/work/index.php?course=ICE262&id=24692","start":1671659940000,"end":1671659940000},
+	{"id":"9","title":"Εξέταση EN101","url":"/modules/agenda/index.php?course=EN101&id=9","start":1671091200000,"end":1671098400000},
+	{"id":"7","title":"Συνάντηση ομάδας","url":"","start":1670932800000,"end":1670936400000}
+]}`
+
+func TestGet(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case strings.HasSuffix(r.URL.Path, "calendar_data.php"):
+				fmt.Fprint(w, calendarJSON)
+			case r.URL.Query().Get("course") == "CS152":
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				fmt.Fprintf(w, agendaPage, r.URL.Query().Get("course"))
+			}
+		},
+	))
+	defer server.Close()
+
+	c := colly.NewCollector()
+	c.WithTransport(testserver.Transport(server))
+
+	opts := &config.Options{BaseDomain: "example.com"}
+	courses := []course.Course{
+		{ID: "CS152", Name: "Αλγόριθμοι"},
+		{ID: "ICE262", Name: "Ανάκτηση Πληροφορίας"},
+	}
+	from := time.Date(2022, 12, 1, 0, 0, 0, 0, assignment.Location)
+	to := time.Date(2023, 1, 1, 0, 0, 0, 0, assignment.Location)
+
+	// Act
+	events, err := Get(context.Background(), opts, courses, from, to, c)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expected := []struct {
+		key   string
+		title string
+		start time.Time
+		end   time.Time
+	}{
+		{
+			key:   "ICE262/43",
+			title: "Εργαστήριο",
+			start: time.Date(2022, 12, 12, 16, 0, 0, 0, assignment.Location),
+		},
+		{
+			key:   "personal/7",
+			title: "Συνάντηση ομάδας",
+			start: time.Date(2022, 12, 13, 14, 0, 0, 0, assignment.Location),
+			end:   time.Date(2022, 12, 13, 15, 0, 0, 0, assignment.Location),
+		},
+		{
+			key:   "ICE262/42",
+			title: "Εξέταση",
+			start: time.Date(2022, 12, 15, 10, 0, 0, 0, assignment.Location),
+			end:   time.Date(2022, 12, 15, 12, 0, 0, 0, assignment.Location),
+		},
+	}
+
+	if len(events) != len(expected) {
+		t.Fatalf("Expected: %v events, Actual: %v", len(expected), events)
+	}
+	for i, e := range expected {
+		actual := events[i]
+		if actual.Key() != e.key || actual.Title != e.title {
+			t.Errorf("Expected: %v %v, Actual: %v %v", e.key, e.title, actual.Key(), actual.Title)
+		}
+		if !actual.Start.Equal(e.start) || !actual.End.Equal(e.end) {
+			t.Errorf("Expected: %v - %v, Actual: %v - %v", e.start, e.end, actual.Start, actual.End)
+		}
+	}
+
+	if events[2].Content != "Αμφιθέατρο Α" || events[2].Course.Name != "Ανάκτηση Πληροφορίας" {
+		t.Errorf("Expected: %v, Actual: %v", "the agenda event of ICE262", events[2])
+	}
+	if events[1].Course != nil {
+		t.Errorf("Expected: %v, Actual: %v", nil, events[1].Course)
+	}
+}
+
+func TestFetchCourse_NoAgendaModule(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		fails  bool
+	}{
+		{name: "not found", status: http.StatusNotFound},
+		{name: "forbidden", status: http.StatusForbidden},
+		{name: "server error", status: http.StatusServiceUnavailable, fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewTLSServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(tt.status)
+				},
+			))
+			defer server.Close()
+
+			c := colly.NewCollector()
+			c.WithTransport(testserver.Transport(server))
+
+			opts := &config.Options{BaseDomain: "example.com"}
+			crs := course.Course{ID: "ICE262", Name: "Ανάκτηση Πληροφορίας"}
+
+			// Act
+			events, err := FetchCourse(context.Background(), opts, &crs, c)
+
+			// Assert
+			if (err != nil) != tt.fails {
+				t.Fatalf("Expected failure: %v, Actual: %v", tt.fails, err)
+			}
+			if len(events) != 0 {
+				t.Errorf("Expected: %v, Actual: %v", 0, events)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/gocolly/colly"
+)
+
+// calendarData is the response of the calendar of the portfolio, with the
+// times in milliseconds since the epoch.
+type calendarData struct {
+	Success int `json:"success"`
+	Result  []struct {
+		ID    json.Number `json:"id"`
+		Title string      `json:"title"`
+		URL   string      `json:"url"`
+		Start json.Number `json:"start"`
+		End   json.Number `json:"end"`
+	} `json:"result"`
+}
+
+// FetchPersonal fetches the events of the personal calendar of the
+// portfolio between from and to. The calendar also lists the deadlines of
+// assignments and exercises, which are left out, and the agenda events of
+// the courses, which are returned as events of the course with the same ID
+// among courses. Agenda events of courses that are not among courses, ex.
+// excluded ones, are left out too.
+func FetchPersonal(
+	ctx context.Context,
+	opts *config.Options,
+	courses []course.Course,
+	from time.Time,
+	to time.Time,
+	c *colly.Collector,
+) ([]Event, error) {
+	byID := make(map[string]*course.Course, len(courses))
+	for i := range courses {
+		byID[courses[i].ID] = &courses[i]
+	}
+
+	var (
+		events []Event
+		err    error
+	)
+
+	c.OnResponse(func(r *colly.Response) {
+		var data calendarData
+		if err = json.Unmarshal(r.Body, &data); err != nil {
+			err = fmt.Errorf("calendar data: %w", err)
+			return
+		}
+		if data.Success != 1 {
+			err = fmt.Errorf("calendar data: not successful")
+			return
+		}
+
+		events = make([]Event, 0, len(data.Result))
+		for _, item := range data.Result {
+			eventURL := ""
+			if item.URL != "" {
+				eventURL = r.Request.AbsoluteURL(item.URL)
+			}
+
+			e, ok := newPersonalEvent(item.ID.String(), item.Title, eventURL, byID)
+			if !ok {
+				continue
+			}
+
+			start, parseErr := item.Start.Int64()
+			if parseErr != nil {
+				continue
+			}
+			e.Start = time.UnixMilli(start).In(assignment.Location)
+			if end, parseErr := item.End.Int64(); parseErr == nil && end > start {
+				e.End = time.UnixMilli(end).In(assignment.Location)
+			}
+
+			events = append(events, e)
+		}
+	})
+
+	dataURL, urlErr := url.Parse("https://" + opts.BaseDomain + "/main/calendar_data.php")
+	if urlErr != nil {
+		return nil, urlErr
+	}
+	values := dataURL.Query()
+	values.Add("from", strconv.FormatInt(from.UnixMilli(), 10))
+	values.Add("to", strconv.FormatInt(to.UnixMilli(), 10))
+	dataURL.RawQuery = values.Encode()
+
+	if visitErr := c.Visit(dataURL.String()); visitErr != nil {
+		return nil, visitErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return events, ctx.Err()
+}
+
+// newPersonalEvent tells the kind of an entry of the personal calendar by
+// its link, and reports false for the entries that are left out.
+func newPersonalEvent(
+	id string,
+	title string,
+	eventURL string,
+	courses map[string]*course.Course,
+) (Event, bool) {
+	e := Event{ID: id, Title: strings.TrimSpace(title), URL: eventURL}
+
+	u, err := url.Parse(eventURL)
+	if err != nil {
+		return e, true
+	}
+
+	switch {
+	case strings.Contains(u.Path, "/modules/work/"),
+		strings.Contains(u.Path, "/modules/exercise/"):
+		return Event{}, false
+	case strings.Contains(u.Path, "/modules/agenda/"):
+		courseID := u.Query().Get("course")
+		agendaID := queryID(eventURL)
+		if courseID == "" || agendaID == "" {
+			return e, true
+		}
+
+		crs, ok := courses[courseID]
+		if !ok {
+			return Event{}, false
+		}
+		e.ID = agendaID
+		e.Course = crs
+	}
+
+	return e, true
+}
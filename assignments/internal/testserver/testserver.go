@@ -0,0 +1,20 @@
+// Package testserver helps the tests of the scrapers run against an
+// httptest server in place of e-class.
+package testserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+)
+
+// Transport routes every request to the TLS test server, whose certificate
+// is valid for example.com.
+func Transport(server *httptest.Server) *http.Transport {
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	return transport
+}
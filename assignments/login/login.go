@@ -1,23 +1,41 @@
 package login
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
+	"os"
+	"strings"
 
 	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/session"
 	"github.com/gocolly/colly"
 )
 
-func headHomepage(url string, c *colly.Collector) error {
+// ErrInvalidCredentials is returned when e-class rejects the username or
+// the password. Network failures are returned as they are instead.
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+func headHomepage(ctx context.Context, url string, c *colly.Collector) error {
 	err := c.Visit("https://" + url)
 	if err != nil {
 		return err
 	}
-	return nil
+	return ctx.Err()
 }
 
-func Login(url string, credentials config.Credentials, c *colly.Collector) error {
+// Login posts the credentials to the e-class homepage, so that the cookies
+// of c carry a logged in session. It returns an error wrapping
+// ErrInvalidCredentials when the login is rejected.
+func Login(
+	ctx context.Context,
+	url string,
+	credentials config.Credentials,
+	c *colly.Collector,
+) error {
 	c.OnError(func(r *colly.Response, err error) {
-		fmt.Println(
+		log.Println(
 			"Request URL:",
 			r.Request.URL,
 			"failed with response:",
@@ -27,12 +45,12 @@ func Login(url string, credentials config.Credentials, c *colly.Collector) error
 		)
 	})
 
-	err := headHomepage(url, c)
+	err := headHomepage(ctx, url, c)
 	if err != nil {
 		return err
 	}
 
-	err = postLogin(url, credentials, c)
+	err = postLogin(ctx, url, credentials, c.Clone())
 	if err != nil {
 		return err
 	}
@@ -40,17 +58,125 @@ func Login(url string, credentials config.Credentials, c *colly.Collector) error
 	return nil
 }
 
-func postLogin(url string, credentials config.Credentials, c *colly.Collector) error {
+// Resume reuses the cookies of c when they still carry a logged in
+// session and logs in with the credentials otherwise.
+func Resume(
+	ctx context.Context,
+	url string,
+	credentials config.Credentials,
+	c *colly.Collector,
+) error {
+	loggedIn, err := IsLoggedIn(ctx, url, c.Clone())
+	if err != nil {
+		return err
+	}
+
+	if loggedIn {
+		return nil
+	}
+
+	return Login(ctx, url, credentials, c)
+}
+
+// ResumeSession is Resume for the collectors of s, reusing the cookies
+// saved at path while they carry a logged in session and saving the ones
+// of the new session there. A file that cannot be read is discarded and a
+// failure to save is only logged, as the session works all the same.
+func ResumeSession(
+	ctx context.Context,
+	s *session.Session,
+	path string,
+	credentials config.Credentials,
+) error {
+	err := s.Load(path, credentials.Username)
+	if err != nil {
+		log.Println("ignoring cached session:", err.Error())
+		if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Println("failed to discard cached session:", err.Error())
+		}
+	}
+
+	err = Resume(ctx, s.BaseDomain, credentials, s.Collector(ctx))
+	if err != nil {
+		return err
+	}
+
+	if err = s.Save(path, credentials.Username); err != nil {
+		log.Println("failed to cache session:", err.Error())
+	}
+	return nil
+}
+
+// IsLoggedIn reports whether the cookies of c carry a logged in session.
+// e-class redirects expired sessions from the portfolio to the login form.
+func IsLoggedIn(ctx context.Context, url string, c *colly.Collector) (bool, error) {
+	var finalPath string
+	c.OnResponse(func(r *colly.Response) {
+		finalPath = r.Request.URL.Path
+	})
+
+	hasLoginForm := false
+	c.OnHTML("input[name=uname]", func(_ *colly.HTMLElement) {
+		hasLoginForm = true
+	})
+
+	err := c.Visit("https://" + url + "/main/portfolio.php")
+	if err != nil {
+		return false, err
+	}
+
+	if err = ctx.Err(); err != nil {
+		return false, err
+	}
+
+	return strings.HasSuffix(finalPath, "/portfolio.php") && !hasLoginForm, nil
+}
+
+func postLogin(
+	ctx context.Context,
+	url string,
+	credentials config.Credentials,
+	c *colly.Collector,
+) error {
 	body := make(map[string]string, 3)
 
 	body["uname"] = credentials.Username
 	body["pass"] = credentials.Password
 	body["submit"] = ""
 
+	var finalPath string
+	c.OnResponse(func(r *colly.Response) {
+		finalPath = r.Request.URL.Path
+	})
+
+	alerts := make([]string, 0, 1)
+	c.OnHTML(".alert-danger, .alert-warning", func(h *colly.HTMLElement) {
+		if text := strings.TrimSpace(h.Text); text != "" {
+			alerts = append(alerts, text)
+		}
+	})
+
 	err := c.Post("https://"+url, body)
 	if err != nil {
 		return err
 	}
 
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	return checkLogin(finalPath, alerts)
+}
+
+// checkLogin inspects the response to the login form. A successful login
+// redirects to the portfolio and shows no alert. The session cookie proves
+// nothing, as the homepage sets one before the login.
+func checkLogin(finalPath string, alerts []string) error {
+	switch {
+	case len(alerts) > 0:
+		return fmt.Errorf("%w: %v", ErrInvalidCredentials, strings.Join(alerts, " "))
+	case !strings.HasSuffix(finalPath, "/portfolio.php"):
+		return fmt.Errorf("%w: not redirected to the portfolio", ErrInvalidCredentials)
+	}
 	return nil
 }
@@ -0,0 +1,149 @@
+package login_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/internal/testserver"
+	"github.com/Huray-hub/eclass-utils/assignments/login"
+	"github.com/Huray-hub/eclass-utils/assignments/session"
+)
+
+// newFakeEclass serves a login form that accepts the given password. As
+// in e-class, the homepage starts a session before the login, which the
+// login then marks as logged in.
+func newFakeEclass(password string) *httptest.Server {
+	var mu sync.Mutex
+	sessions := 0
+	loggedIn := make(map[string]bool)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		cookie, err := r.Cookie("PHPSESSID")
+		if err != nil {
+			sessions++
+			cookie = &http.Cookie{Name: "PHPSESSID", Value: fmt.Sprint("s3cr3t", sessions), Path: "/"}
+			http.SetCookie(w, cookie)
+		}
+
+		loginForm := `<form method="post"><input name="uname"><input name="pass"></form>`
+		switch {
+		case r.Method != http.MethodPost:
+			fmt.Fprint(w, loginForm)
+		case r.FormValue("pass") == "":
+			// rejected without an alert
+			fmt.Fprint(w, loginForm)
+		case r.FormValue("pass") != password:
+			fmt.Fprint(w, `<div class="alert alert-warning">Λάθος στοιχεία σύνδεσης</div>`)
+		default:
+			loggedIn[cookie.Value] = true
+			http.Redirect(w, r, "/main/portfolio.php", http.StatusFound)
+		}
+	})
+	mux.HandleFunc("/main/portfolio.php", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if cookie, err := r.Cookie("PHPSESSID"); err != nil || !loggedIn[cookie.Value] {
+			http.Redirect(w, r, "/", http.StatusFound)
+			return
+		}
+		fmt.Fprint(w, `<div id="main-content">Χαρτοφυλάκιο</div>`)
+	})
+
+	return httptest.NewTLSServer(mux)
+}
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		expected error
+	}{
+		{"valid credentials", "correct", nil},
+		{"invalid credentials", "wrong", login.ErrInvalidCredentials},
+		{"rejected without an alert", "", login.ErrInvalidCredentials},
+	}
+
+	server := newFakeEclass("correct")
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			c := session.New("example.com", testserver.Transport(server)).Collector(ctx)
+			creds := config.Credentials{Username: "student", Password: tt.password}
+
+			// Act
+			err := login.Login(ctx, "example.com", creds, c)
+
+			// Assert
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected: %v, Actual: %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestResume_ReusesSavedSession(t *testing.T) {
+	// Arrange
+	server := newFakeEclass("correct")
+	defer server.Close()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "session.json")
+	creds := config.Credentials{Username: "student", Password: "correct"}
+
+	first := session.New("example.com", testserver.Transport(server))
+	err := login.Login(ctx, "example.com", creds, first.Collector(ctx))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err = first.Save(path, creds.Username); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	second := session.New("example.com", testserver.Transport(server))
+	if err = second.Load(path, creds.Username); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Act
+	// a wrong password proves that the saved session was used
+	creds.Password = "wrong"
+	err = login.Resume(ctx, "example.com", creds, second.Collector(ctx))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected the saved session to be reused, Actual: %v", err)
+	}
+}
+
+func TestResume_ExpiredSession(t *testing.T) {
+	// Arrange
+	server := newFakeEclass("correct")
+	defer server.Close()
+
+	ctx := context.Background()
+	s := session.New("example.com", testserver.Transport(server))
+	creds := config.Credentials{Username: "student", Password: "wrong"}
+
+	// Act
+	err := login.Resume(ctx, "example.com", creds, s.Collector(ctx))
+
+	// Assert
+	if !errors.Is(err, login.ErrInvalidCredentials) {
+		t.Errorf("Expected: %v, Actual: %v", login.ErrInvalidCredentials, err)
+	}
+}
@@ -0,0 +1,326 @@
+// Package professor gathers the contact information of the instructors of
+// the enrolled courses from the course pages.
+package professor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/text"
+	"github.com/gocolly/colly"
+	"golang.org/x/net/html"
+)
+
+// Professor is an instructor of the enrolled courses, with the contact
+// information found in the pages of the courses they teach.
+type Professor struct {
+	Name        string
+	Emails      []string
+	Phones      []string
+	OfficeHours []string
+	Courses     []course.Course
+}
+
+// key identifies the professor across courses, by email if known.
+func (p *Professor) key() string {
+	if len(p.Emails) > 0 {
+		return strings.ToLower(p.Emails[0])
+	}
+	return text.Normalize(p.Name)
+}
+
+// Get fetches the professors of the courses, deduplicated and sorted by
+// name. When only some of the courses fail, the professors of the rest are
+// returned along with an error listing the failures.
+func Get(
+	ctx context.Context,
+	opts *config.Options,
+	courses []course.Course,
+	c *colly.Collector,
+) ([]Professor, error) {
+	professors := make([]Professor, 0, len(courses))
+	var failures []string
+
+	for _, crs := range courses {
+		ppc, err := FetchCourse(ctx, opts, crs, c.Clone())
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("course %v: %v", crs.ID, err))
+			continue
+		}
+		professors = append(professors, ppc...)
+	}
+
+	var err error
+	if len(failures) > 0 {
+		err = fmt.Errorf(
+			"failed to fetch professors of %v course(s):\n%v",
+			len(failures),
+			strings.Join(failures, "\n"),
+		)
+		if len(failures) == len(courses) {
+			return nil, err
+		}
+	}
+	return Dedupe(professors), err
+}
+
+// FetchCourse reads the instructors of crs from its home page and their
+// contact information from its description.
+func FetchCourse(
+	ctx context.Context,
+	opts *config.Options,
+	crs course.Course,
+	c *colly.Collector,
+) ([]Professor, error) {
+	homeURL, err := crs.PrepareHomeURL(opts.BaseDomain)
+	if err != nil {
+		return nil, err
+	}
+	descriptionURL, err := crs.PrepareDescriptionURL(opts.BaseDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	home, err := pageLines(ctx, "https://"+homeURL, c.Clone())
+	if err != nil {
+		return nil, err
+	}
+	description, err := pageLines(ctx, "https://"+descriptionURL, c.Clone())
+	if err != nil {
+		return nil, err
+	}
+
+	professors := parseInstructors(home)
+	for i := range professors {
+		professors[i].Courses = []course.Course{crs}
+	}
+	parseContacts(append(home, description...), professors)
+
+	return professors, nil
+}
+
+// pageLines returns the lines of text of the main content of the page at
+// pageURL, with the addresses of mailto links as lines of their own.
+func pageLines(ctx context.Context, pageURL string, c *colly.Collector) ([]string, error) {
+	var lines []string
+
+	c.OnHTML("#main-content", func(h *colly.HTMLElement) {
+		var b strings.Builder
+		for _, n := range h.DOM.Nodes {
+			writeText(&b, n)
+		}
+
+		for _, line := range strings.Split(b.String(), "\n") {
+			if line = strings.Join(strings.Fields(line), " "); line != "" {
+				lines = append(lines, line)
+			}
+		}
+	})
+
+	err := c.Visit(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	return lines, ctx.Err()
+}
+
+var blockElements = map[string]struct{}{
+	"p": {}, "div": {}, "li": {}, "tr": {}, "br": {}, "dd": {}, "dt": {},
+	"h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {}, "table": {},
+}
+
+// writeText writes the text of n, breaking the lines at block elements.
+func writeText(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(n.Data)
+		return
+	case html.ElementNode:
+		if n.Data == "script" || n.Data == "style" {
+			return
+		}
+		if n.Data == "a" {
+			for _, attr := range n.Attr {
+				if attr.Key == "href" && strings.HasPrefix(attr.Val, "mailto:") {
+					b.WriteString(" " + strings.TrimPrefix(attr.Val, "mailto:") + " ")
+				}
+			}
+		}
+	}
+
+	_, block := blockElements[n.Data]
+	if block {
+		b.WriteString("\n")
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writeText(b, child)
+	}
+	if block {
+		b.WriteString("\n")
+	}
+}
+
+var (
+	instructorLabel = regexp.MustCompile(`(?i)^(διδ[άα]σκ\S*|εκπαιδευτ\S*|instructors?)\s*:?\s*`)
+	nameSeparator   = regexp.MustCompile(`\s*(,|;|\s και\s|\s-\s)\s*`)
+	emailPattern    = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phonePattern    = regexp.MustCompile(`(\+30[\s-]?)?(2\d|69)([\s.-]?\d){8}`)
+)
+
+// parseInstructors reads the names after the instructor label of the
+// course home page, ex. "Διδάσκοντες: Α. Παπαδόπουλος, Β. Γεωργίου".
+func parseInstructors(lines []string) []Professor {
+	for i, line := range lines {
+		label := instructorLabel.FindString(line)
+		if label == "" {
+			continue
+		}
+
+		names := strings.TrimSpace(line[len(label):])
+		if names == "" && i+1 < len(lines) {
+			names = lines[i+1]
+		}
+
+		professors := make([]Professor, 0, 2)
+		for _, name := range nameSeparator.Split(names, -1) {
+			if name = strings.TrimSpace(name); name != "" {
+				professors = append(professors, Professor{Name: name})
+			}
+		}
+		return professors
+	}
+	return nil
+}
+
+// parseContacts assigns the emails, phones and office hours of the lines
+// to the professor mentioned last before them, or to the only professor of
+// the course.
+func parseContacts(lines []string, professors []Professor) {
+	var current *Professor
+	if len(professors) == 1 {
+		current = &professors[0]
+	}
+
+	for i, line := range lines {
+		if p := mentioned(line, professors); p != nil {
+			current = p
+		}
+		if current == nil {
+			continue
+		}
+
+		for _, email := range emailPattern.FindAllString(line, -1) {
+			current.Emails = appendUnique(current.Emails, email)
+		}
+		for _, phone := range phonePattern.FindAllString(line, -1) {
+			current.Phones = appendUnique(current.Phones, normalizePhone(phone))
+		}
+		if hours := officeHours(lines, i); hours != "" {
+			current.OfficeHours = appendUnique(current.OfficeHours, hours)
+		}
+	}
+}
+
+// mentioned returns the professor whose surname, the last word of the
+// name, is in line.
+func mentioned(line string, professors []Professor) *Professor {
+	normalized := text.Normalize(line)
+	for i := range professors {
+		words := strings.Fields(professors[i].Name)
+		if len(words) == 0 {
+			continue
+		}
+		surname := text.Normalize(strings.Trim(words[len(words)-1], "."))
+		if len([]rune(surname)) > 2 && strings.Contains(normalized, surname) {
+			return &professors[i]
+		}
+	}
+	return nil
+}
+
+// officeHours returns the office hours of lines[i], if it is labeled as
+// such, taking the next line when the label stands alone.
+func officeHours(lines []string, i int) string {
+	line := lines[i]
+	if !strings.Contains(text.Normalize(line), text.Normalize("ώρες γραφείου")) {
+		return ""
+	}
+
+	_, hours, found := strings.Cut(line, ":")
+	hours = strings.TrimSpace(hours)
+	if !found {
+		hours = line
+	}
+	if hours == "" && i+1 < len(lines) {
+		hours = lines[i+1]
+	}
+	return hours
+}
+
+// normalizePhone strips the separators and the country code of a phone
+// number.
+func normalizePhone(phone string) string {
+	phone = strings.TrimPrefix(strings.TrimSpace(phone), "+30")
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+// Dedupe merges the professors that teach several courses, and sorts them
+// by name.
+func Dedupe(professors []Professor) []Professor {
+	res := make([]Professor, 0, len(professors))
+	index := make(map[string]int, len(professors))
+	names := make(map[string]int, len(professors))
+
+	for _, p := range professors {
+		i, ok := index[p.key()]
+		if !ok {
+			i, ok = names[text.Normalize(p.Name)]
+		}
+		if !ok {
+			i = len(res)
+			res = append(res, Professor{Name: p.Name})
+		}
+
+		merged := &res[i]
+		for _, email := range p.Emails {
+			merged.Emails = appendUnique(merged.Emails, email)
+		}
+		for _, phone := range p.Phones {
+			merged.Phones = appendUnique(merged.Phones, phone)
+		}
+		for _, hours := range p.OfficeHours {
+			merged.OfficeHours = appendUnique(merged.OfficeHours, hours)
+		}
+		merged.Courses = append(merged.Courses, p.Courses...)
+
+		index[merged.key()] = i
+		names[text.Normalize(merged.Name)] = i
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return text.Normalize(res[i].Name) < text.Normalize(res[j].Name)
+	})
+	return res
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,138 @@
+package professor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/internal/testserver"
+	"github.com/gocolly/colly"
+)
+
+const homePage = `<html><body><div id="main-content">
+<h2>Ανάκτηση Πληροφορίας</h2>
+<div><strong>Διδάσκοντες:</strong> Γεώργιος Παπαδόπουλος, Μαρία Γεωργίου</div>
+</div></body></html>`
+
+const descriptionPage = `<html><body><div id="main-content">
+<div class="panel-body">
+	<p>Γ. ΠΑΠΑΔΟΠΟΥΛΟΣ<br>Email: <a href="mailto:gpapad@uniwa.gr">Αποστολή</a><br>
+	Τηλ.: 210 538 5000<br>Ώρες γραφείου: Τρίτη 10:00-12:00</p>
+	<p>Μ. Γεωργίου, mgeorgiou@uniwa.gr, +30 69 1234 5678</p>
+</div>
+</div></body></html>`
+
+func TestFetchCourse(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/courses/") {
+				fmt.Fprint(w, homePage)
+				return
+			}
+			fmt.Fprint(w, descriptionPage)
+		},
+	))
+	defer server.Close()
+
+	c := colly.NewCollector()
+	c.WithTransport(testserver.Transport(server))
+
+	opts := &config.Options{BaseDomain: "example.com"}
+
+	// Act
+	professors, err := FetchCourse(context.Background(), opts, course.Course{ID: "ICE262"}, c)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(professors) != 2 {
+		t.Fatalf("Expected: %v, Actual: %v", 2, professors)
+	}
+
+	expected := []Professor{
+		{
+			Name:        "Γεώργιος Παπαδόπουλος",
+			Emails:      []string{"gpapad@uniwa.gr"},
+			Phones:      []string{"2105385000"},
+			OfficeHours: []string{"Τρίτη 10:00-12:00"},
+		},
+		{
+			Name:   "Μαρία Γεωργίου",
+			Emails: []string{"mgeorgiou@uniwa.gr"},
+			Phones: []string{"6912345678"},
+		},
+	}
+	for i := range expected {
+		actual := professors[i]
+		if actual.Name != expected[i].Name ||
+			fmt.Sprint(actual.Emails) != fmt.Sprint(expected[i].Emails) ||
+			fmt.Sprint(actual.Phones) != fmt.Sprint(expected[i].Phones) ||
+			fmt.Sprint(actual.OfficeHours) != fmt.Sprint(expected[i].OfficeHours) {
+			t.Errorf("Expected: %+v, Actual: %+v", expected[i], actual)
+		}
+	}
+}
+
+func TestGet_PartialFailure(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case strings.Contains(r.URL.String(), "CS152"):
+				w.WriteHeader(http.StatusInternalServerError)
+			case strings.HasPrefix(r.URL.Path, "/courses/"):
+				fmt.Fprint(w, homePage)
+			default:
+				fmt.Fprint(w, descriptionPage)
+			}
+		},
+	))
+	defer server.Close()
+
+	c := colly.NewCollector()
+	c.WithTransport(testserver.Transport(server))
+
+	opts := &config.Options{BaseDomain: "example.com"}
+	courses := []course.Course{{ID: "CS152"}, {ID: "ICE262"}}
+
+	// Act
+	professors, err := Get(context.Background(), opts, courses, c)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "CS152") {
+		t.Errorf("Expected: %v, Actual: %v", "the failure of CS152", err)
+	}
+	if len(professors) != 2 {
+		t.Errorf("Expected: %v, Actual: %v", 2, professors)
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	// Arrange
+	ir := course.Course{ID: "ICE262"}
+	algo := course.Course{ID: "CS152"}
+	professors := []Professor{
+		{Name: "Γεώργιος Παπαδόπουλος", Emails: []string{"gpapad@uniwa.gr"}, Courses: []course.Course{ir}},
+		{Name: "Μαρία Γεωργίου", Courses: []course.Course{ir}},
+		{Name: "ΓΕΩΡΓΙΟΣ ΠΑΠΑΔΟΠΟΥΛΟΣ", Phones: []string{"2105385000"}, Courses: []course.Course{algo}},
+	}
+
+	// Act
+	res := Dedupe(professors)
+
+	// Assert
+	if len(res) != 2 {
+		t.Fatalf("Expected: %v, Actual: %v", 2, res)
+	}
+	p := res[0]
+	if p.Name != "Γεώργιος Παπαδόπουλος" || len(p.Courses) != 2 || len(p.Phones) != 1 {
+		t.Errorf("Expected the courses and phones merged, Actual: %+v", p)
+	}
+}
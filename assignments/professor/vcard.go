@@ -0,0 +1,98 @@
+package professor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineLength is the length in octets after which vCard lines are
+// folded.
+const maxLineLength = 75
+
+// WriteVCards writes the professors as vCard 3.0 contacts, for importing
+// them into an address book.
+func WriteVCards(w io.Writer, professors []Professor) error {
+	for _, p := range professors {
+		lines := []string{
+			"BEGIN:VCARD",
+			"VERSION:3.0",
+			"FN:" + escape(p.Name),
+			"N:" + structuredName(p.Name),
+		}
+		for _, email := range p.Emails {
+			lines = append(lines, "EMAIL;TYPE=INTERNET,WORK:"+escape(email))
+		}
+		for _, phone := range p.Phones {
+			lines = append(lines, "TEL;TYPE=WORK,VOICE:"+escape(phone))
+		}
+		if note := note(p); note != "" {
+			lines = append(lines, "NOTE:"+escape(note))
+		}
+		lines = append(lines, "END:VCARD")
+
+		for _, line := range lines {
+			if _, err := io.WriteString(w, fold(line)+"\r\n"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// structuredName splits the name into the family name, its last word, and
+// the given names.
+func structuredName(name string) string {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return ";;;;"
+	}
+
+	family := words[len(words)-1]
+	given := strings.Join(words[:len(words)-1], " ")
+	return fmt.Sprintf("%v;%v;;;", escape(family), escape(given))
+}
+
+// note lists the courses and the office hours of the professor.
+func note(p Professor) string {
+	parts := make([]string, 0, 2)
+
+	if len(p.Courses) > 0 {
+		courses := make([]string, 0, len(p.Courses))
+		for _, crs := range p.Courses {
+			courses = append(courses, fmt.Sprintf("%v (%v)", crs.Name, crs.ID))
+		}
+		parts = append(parts, "Μαθήματα: "+strings.Join(courses, ", "))
+	}
+	if len(p.OfficeHours) > 0 {
+		parts = append(parts, "Ώρες γραφείου: "+strings.Join(p.OfficeHours, "; "))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\n", `\n`)
+
+func escape(value string) string {
+	return escaper.Replace(value)
+}
+
+// fold splits line into lines of at most maxLineLength octets, without
+// splitting characters, continued with a leading space.
+func fold(line string) string {
+	var b strings.Builder
+
+	length := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if length+size > maxLineLength {
+			b.WriteString("\r\n ")
+			length = 1
+		}
+		b.WriteRune(r)
+		length += size
+	}
+
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package professor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+func TestWriteVCards(t *testing.T) {
+	// Arrange
+	professors := []Professor{
+		{
+			Name:        "Γεώργιος Παπαδόπουλος",
+			Emails:      []string{"gpapad@uniwa.gr"},
+			Phones:      []string{"2105385000"},
+			OfficeHours: []string{"Τρίτη 10:00-12:00, Πέμπτη 12:00-14:00"},
+			Courses:     []course.Course{{ID: "ICE262", Name: "Ανάκτηση Πληροφορίας"}},
+		},
+	}
+
+	// Act
+	var b bytes.Buffer
+	err := WriteVCards(&b, professors)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	vcard := b.String()
+	for _, expected := range []string{
+		"BEGIN:VCARD\r\nVERSION:3.0\r\n",
+		"FN:Γεώργιος Παπαδόπουλος\r\n",
+		"N:Παπαδόπουλος;Γεώργιος;;;\r\n",
+		"EMAIL;TYPE=INTERNET,WORK:gpapad@uniwa.gr\r\n",
+		"TEL;TYPE=WORK,VOICE:2105385000\r\n",
+		"END:VCARD\r\n",
+	} {
+		if !strings.Contains(vcard, expected) {
+			t.Errorf("Expected: %q in\n%v", expected, vcard)
+		}
+	}
+
+	for _, line := range strings.Split(vcard, "\r\n") {
+		if len(line) > maxLineLength {
+			t.Errorf("Expected lines of at most %v octets, Actual: %v", maxLineLength, len(line))
+		}
+	}
+
+	unfolded := strings.ReplaceAll(vcard, "\r\n ", "")
+	expectedNote := `NOTE:Μαθήματα: Ανάκτηση Πληροφορίας (ICE262)\nΏρες γραφείου: Τρίτη 10:00-12:00\, Πέμπτη 12:00-14:00`
+	if !strings.Contains(unfolded, expectedNote) {
+		t.Errorf("Expected: %v in\n%v", expectedNote, unfolded)
+	}
+}
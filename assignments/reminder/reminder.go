@@ -0,0 +1,172 @@
+// Package reminder decides when to remind of the deadlines of the
+// assignments that are not submitted yet.
+package reminder
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+)
+
+// daysPattern matches a lead time in days, optionally followed by a Go
+// duration, like "7d" or "1d12h".
+var daysPattern = regexp.MustCompile(`^(\d+)d(.*)$`)
+
+// Reminder is a reminder of the deadline of an assignment, LeadTime before
+// it.
+type Reminder struct {
+	Assignment assignment.Assignment
+	LeadTime   time.Duration
+}
+
+// Engine finds the reminders that are due, each only once, even across
+// restarts.
+type Engine struct {
+	// LeadTimes are the lead times of every course, except for the ones in
+	// CourseLeadTimes.
+	LeadTimes       []time.Duration
+	CourseLeadTimes map[string][]time.Duration
+	// StatePath is the file that keeps the fired reminders, see StatePath.
+	StatePath string
+	// Now returns the current time, time.Now if not set.
+	Now func() time.Time
+
+	state *State
+}
+
+// New creates an engine with the lead times of opts.Reminders and
+// opts.CourseReminders.
+func New(opts *config.Options, statePath string) (*Engine, error) {
+	leadTimes, err := ParseLeadTimes(opts.Reminders)
+	if err != nil {
+		return nil, err
+	}
+
+	courseLeadTimes := make(map[string][]time.Duration, len(opts.CourseReminders))
+	for courseID, reminders := range opts.CourseReminders {
+		courseLeadTimes[courseID], err = ParseLeadTimes(reminders)
+		if err != nil {
+			return nil, fmt.Errorf("course %v: %w", courseID, err)
+		}
+	}
+
+	return &Engine{
+		LeadTimes:       leadTimes,
+		CourseLeadTimes: courseLeadTimes,
+		StatePath:       statePath,
+	}, nil
+}
+
+// ParseLeadTime parses a lead time in days, like "7d" or "1d12h", or as a
+// Go duration, like "3h" or "90m".
+func ParseLeadTime(raw string) (time.Duration, error) {
+	var lead time.Duration
+
+	rest := raw
+	if match := daysPattern.FindStringSubmatch(raw); match != nil {
+		days, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid lead time %q: %w", raw, err)
+		}
+		lead = time.Duration(days) * 24 * time.Hour
+		rest = match[2]
+	}
+
+	if rest != "" {
+		d, err := time.ParseDuration(rest)
+		if err != nil {
+			return 0, fmt.Errorf("invalid lead time %q: %w", raw, err)
+		}
+		lead += d
+	}
+
+	if lead <= 0 {
+		return 0, fmt.Errorf("invalid lead time %q: must be positive", raw)
+	}
+	return lead, nil
+}
+
+// ParseLeadTimes parses every lead time, see ParseLeadTime.
+func ParseLeadTimes(raw []string) ([]time.Duration, error) {
+	leadTimes := make([]time.Duration, 0, len(raw))
+	for _, r := range raw {
+		lead, err := ParseLeadTime(r)
+		if err != nil {
+			return nil, err
+		}
+		leadTimes = append(leadTimes, lead)
+	}
+	return leadTimes, nil
+}
+
+// Due returns the reminders of the assignments that are due and were not
+// fired before, and keeps them as fired. Submitted and expired
+// assignments have none. When several lead times of an assignment have
+// passed since the last call, only the shortest one is returned and the
+// rest are dropped.
+func (e *Engine) Due(assignments []assignment.Assignment) ([]Reminder, error) {
+	if e.state == nil {
+		state, err := LoadState(e.StatePath)
+		if err != nil {
+			return nil, err
+		}
+		e.state = state
+	}
+
+	now := e.now()
+	due := make([]Reminder, 0)
+
+	for _, a := range assignments {
+		if a.IsSent || !a.Deadline.After(now) {
+			continue
+		}
+
+		var shortest time.Duration
+		for _, lead := range e.leadTimes(a.Course.ID) {
+			key := firedKey(a, lead)
+			if a.Deadline.Add(-lead).After(now) || e.state.isFired(key) {
+				continue
+			}
+			e.state.Fired[key] = a.Deadline
+			if shortest == 0 || lead < shortest {
+				shortest = lead
+			}
+		}
+
+		if shortest > 0 {
+			due = append(due, Reminder{Assignment: a, LeadTime: shortest})
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].Assignment.Deadline.Before(due[j].Assignment.Deadline)
+	})
+
+	e.state.prune(now)
+	return due, e.state.Save(e.StatePath)
+}
+
+func (e *Engine) leadTimes(courseID string) []time.Duration {
+	if leadTimes, ok := e.CourseLeadTimes[courseID]; ok {
+		return leadTimes
+	}
+	return e.LeadTimes
+}
+
+func (e *Engine) now() time.Time {
+	if e.Now != nil {
+		return e.Now()
+	}
+	return time.Now()
+}
+
+// firedKey identifies a reminder. It includes the deadline, so that a
+// moved deadline is reminded of again.
+func firedKey(a assignment.Assignment, lead time.Duration) string {
+	return fmt.Sprintf("%v@%v-%v", a.Key(), a.Deadline.Unix(), lead)
+}
@@ -0,0 +1,170 @@
+package reminder
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+func TestParseLeadTime(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected time.Duration
+		fails    bool
+	}{
+		{raw: "7d", expected: 7 * 24 * time.Hour},
+		{raw: "3h", expected: 3 * time.Hour},
+		{raw: "1d12h", expected: 36 * time.Hour},
+		{raw: "90m", expected: 90 * time.Minute},
+		{raw: "0d", fails: true},
+		{raw: "-3h", fails: true},
+		{raw: "soon", fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			// Act
+			actual, err := ParseLeadTime(tt.raw)
+
+			// Assert
+			if (err != nil) != tt.fails {
+				t.Fatalf("Expected failure: %v, Actual: %v", tt.fails, err)
+			}
+			if actual != tt.expected {
+				t.Errorf("Expected: %v, Actual: %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// clock is a settable clock for the engine.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func TestEngineDue(t *testing.T) {
+	// Arrange
+	start := time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC)
+	ice262 := &course.Course{ID: "ICE262"}
+	cs152 := &course.Course{ID: "CS152"}
+
+	pending := assignment.Assignment{ID: "1", Course: ice262, Deadline: start.AddDate(0, 0, 10)}
+	sent := assignment.Assignment{ID: "2", Course: ice262, Deadline: start.AddDate(0, 0, 5), IsSent: true}
+	overridden := assignment.Assignment{ID: "3", Course: cs152, Deadline: start.AddDate(0, 0, 4)}
+	assignments := []assignment.Assignment{pending, sent, overridden}
+
+	opts := &config.Options{
+		Reminders:       []string{"7d", "1d", "3h"},
+		CourseReminders: map[string][]string{"CS152": {"2d"}},
+	}
+	statePath := filepath.Join(t.TempDir(), "reminders.json")
+	c := &clock{now: start}
+
+	newEngine := func() *Engine {
+		e, err := New(opts, statePath)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		e.Now = c.Now
+		return e
+	}
+
+	steps := []struct {
+		name     string
+		now      time.Time
+		restart  bool
+		expected []Reminder
+	}{
+		{
+			name:     "none due yet",
+			now:      start,
+			expected: []Reminder{},
+		},
+		{
+			name: "7d of the default and 2d of the override",
+			now:  start.AddDate(0, 0, 3),
+			expected: []Reminder{
+				{Assignment: overridden, LeadTime: 48 * time.Hour},
+				{Assignment: pending, LeadTime: 7 * 24 * time.Hour},
+			},
+		},
+		{
+			name:     "not fired again after a restart",
+			now:      start.AddDate(0, 0, 3).Add(time.Hour),
+			restart:  true,
+			expected: []Reminder{},
+		},
+		{
+			name:     "only the shortest of the lead times missed",
+			now:      pending.Deadline.Add(-time.Hour),
+			expected: []Reminder{{Assignment: pending, LeadTime: 3 * time.Hour}},
+		},
+		{
+			name:     "none after the deadline",
+			now:      pending.Deadline.Add(time.Hour),
+			expected: []Reminder{},
+		},
+	}
+
+	e := newEngine()
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			c.now = step.now
+			if step.restart {
+				e = newEngine()
+			}
+
+			// Act
+			actual, err := e.Due(assignments)
+
+			// Assert
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if len(actual) != len(step.expected) {
+				t.Fatalf("Expected: %v, Actual: %v", step.expected, actual)
+			}
+			for i := range actual {
+				if actual[i].Assignment.Key() != step.expected[i].Assignment.Key() ||
+					actual[i].LeadTime != step.expected[i].LeadTime {
+					t.Errorf("Expected: %v, Actual: %v", step.expected[i], actual[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEngineDue_MovedDeadline(t *testing.T) {
+	// Arrange
+	now := time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC)
+	a := assignment.Assignment{ID: "1", Course: &course.Course{ID: "ICE262"}, Deadline: now.Add(2 * time.Hour)}
+
+	e := &Engine{
+		LeadTimes: []time.Duration{3 * time.Hour},
+		StatePath: filepath.Join(t.TempDir(), "reminders.json"),
+		Now:       func() time.Time { return now },
+	}
+	if _, err := e.Due([]assignment.Assignment{a}); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Act
+	a.Deadline = a.Deadline.Add(30 * time.Minute)
+	actual, err := e.Due([]assignment.Assignment{a})
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(actual) != 1 {
+		t.Errorf("Expected: %v, Actual: %v", 1, len(actual))
+	}
+}
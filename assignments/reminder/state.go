@@ -0,0 +1,73 @@
+package reminder
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+)
+
+// State remembers the reminders that were already fired, so that a
+// restart does not fire them again.
+type State struct {
+	// Fired maps the key of every fired reminder to the deadline it was
+	// about.
+	Fired map[string]time.Time `json:"fired"`
+}
+
+// StatePath is the file in the cache directory that keeps the State.
+func StatePath() (string, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "reminders.json"), nil
+}
+
+// LoadState reads the state at path. A missing file is an empty state,
+// where no reminder was fired.
+func LoadState(path string) (*State, error) {
+	state := &State{Fired: make(map[string]time.Time)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Fired == nil {
+		state.Fired = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// Save writes the state to path.
+func (s *State) Save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (s *State) isFired(key string) bool {
+	_, ok := s.Fired[key]
+	return ok
+}
+
+// prune forgets the reminders of the deadlines that have passed.
+func (s *State) prune(now time.Time) {
+	for key, deadline := range s.Fired {
+		if deadline.Before(now) {
+			delete(s.Fired, key)
+		}
+	}
+}
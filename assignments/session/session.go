@@ -0,0 +1,71 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+
+	"github.com/gocolly/colly"
+)
+
+// Session holds the cookies of a logged in user, shared by every collector
+// it creates.
+type Session struct {
+	BaseDomain string
+	jar        *cookiejar.Jar
+	transport  http.RoundTripper
+}
+
+// New creates an empty session for the given e-class domain. A nil
+// transport means http.DefaultTransport.
+func New(baseDomain string, transport http.RoundTripper) *Session {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Session{
+		BaseDomain: baseDomain,
+		jar:        newJar(),
+		transport:  transport,
+	}
+}
+
+// Collector returns a new collector that shares the session's cookies. Its
+// requests, including the ones in flight, are cancelled along with ctx.
+func (s *Session) Collector(ctx context.Context) *colly.Collector {
+	c := colly.NewCollector(
+		colly.AllowedDomains(s.BaseDomain),
+	)
+	c.SetCookieJar(s.jar)
+	c.WithTransport(&contextTransport{ctx: ctx, base: s.transport})
+
+	return c
+}
+
+// Client returns an HTTP client that shares the session's cookies, for the
+// requests that collectors do not suit, ex. streaming large files. Its
+// requests are cancelled along with ctx.
+func (s *Session) Client(ctx context.Context) *http.Client {
+	return &http.Client{
+		Jar:       s.jar,
+		Transport: &contextTransport{ctx: ctx, base: s.transport},
+	}
+}
+
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
+func newJar() *cookiejar.Jar {
+	// cookiejar.New never fails without options
+	jar, _ := cookiejar.New(nil)
+	return jar
+}
@@ -0,0 +1,102 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+)
+
+type storedSession struct {
+	BaseDomain string          `json:"baseDomain"`
+	Username   string          `json:"username"`
+	SavedAt    time.Time       `json:"savedAt"`
+	Cookies    []storedCookies `json:"cookies"`
+}
+
+type storedCookies struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// DefaultPath is the file in the cache directory that keeps the session
+// between runs.
+func DefaultPath() (string, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "session.json"), nil
+}
+
+// Load restores the cookies saved at path, provided they were saved for
+// the same domain and username. A missing file is not an error, the
+// session just stays empty.
+func (s *Session) Load(path, username string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var stored storedSession
+	if err = json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	if stored.BaseDomain != s.BaseDomain || stored.Username != username {
+		return nil
+	}
+
+	cookies := make([]*http.Cookie, 0, len(stored.Cookies))
+	for _, c := range stored.Cookies {
+		cookies = append(cookies, &http.Cookie{Name: c.Name, Value: c.Value, Path: "/"})
+	}
+	s.jar.SetCookies(s.url(), cookies)
+
+	return nil
+}
+
+// Save writes the session's cookies to path, readable only by the user.
+func (s *Session) Save(path, username string) error {
+	cookies := s.jar.Cookies(s.url())
+
+	stored := storedSession{
+		BaseDomain: s.BaseDomain,
+		Username:   username,
+		SavedAt:    time.Now(),
+		Cookies:    make([]storedCookies, 0, len(cookies)),
+	}
+	for _, c := range cookies {
+		stored.Cookies = append(stored.Cookies, storedCookies{Name: c.Name, Value: c.Value})
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(path, data, 0600)
+	if err != nil {
+		return err
+	}
+
+	// WriteFile keeps the permissions of an existing file
+	return os.Chmod(path, 0600)
+}
+
+func (s *Session) url() *url.URL {
+	return &url.URL{Scheme: "https", Host: s.BaseDomain, Path: "/"}
+}
@@ -0,0 +1,222 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/login"
+)
+
+// Version of the snapshot file format. Snapshots of other versions are
+// not loaded.
+const Version = 1
+
+// ErrNoSnapshot is returned when there is no snapshot to fall back to.
+var ErrNoSnapshot = errors.New("no offline snapshot found")
+
+// Snapshot is the result of a successful fetch, kept in the cache
+// directory for offline use. It has every course that is not excluded
+// and every assignment of them, so that changing the excluded assignments
+// or IncludeExpired applies to it as well; see Filter.
+type Snapshot struct {
+	Version     int                     `json:"version"`
+	FetchedAt   time.Time               `json:"fetchedAt"`
+	Courses     []course.Course         `json:"courses"`
+	Assignments []assignment.Assignment `json:"assignments"`
+
+	// Offline is set when the snapshot was loaded from the cache instead
+	// of being fetched now.
+	Offline bool `json:"-"`
+	// FetchErr is the failure that made Fetch fall back to the cache.
+	FetchErr error `json:"-"`
+}
+
+func newSnapshot(
+	courses []course.Course,
+	assignments []assignment.Assignment,
+	fetchedAt time.Time,
+) *Snapshot {
+	return &Snapshot{
+		Version:     Version,
+		FetchedAt:   fetchedAt,
+		Courses:     courses,
+		Assignments: assignments,
+	}
+}
+
+// Age is how long ago the snapshot was fetched.
+func (s *Snapshot) Age() time.Duration {
+	return time.Since(s.FetchedAt)
+}
+
+// Source fetches the courses and the assignments of a snapshot, ex. an
+// *eclass.Client created with the options of Unfiltered.
+type Source interface {
+	Login(ctx context.Context) error
+	Courses(ctx context.Context) ([]course.Course, error)
+	CoursesAssignments(ctx context.Context, courses []course.Course) ([]assignment.Assignment, error)
+}
+
+// Unfiltered returns a copy of opts that keeps every assignment of the
+// courses that are not excluded, for the Source of Fetch. Excluded courses
+// stay out of the fetch, so that one that fails does not keep every
+// snapshot from being saved.
+func Unfiltered(opts config.Options) config.Options {
+	opts.IncludeExpired = true
+	opts.ExcludedAssignments = nil
+	return opts
+}
+
+// Fetch logs in to src and gets the courses and the assignments and, when
+// every course succeeds, saves them as the new snapshot. With
+// opts.Offline, or when fetching fails for any reason but rejected
+// credentials, the last snapshot is returned instead.
+func Fetch(ctx context.Context, opts *config.Options, src Source) (*Snapshot, error) {
+	if opts.Offline {
+		return Load()
+	}
+
+	courses, assignments, err := fetch(ctx, src)
+
+	var partial *assignment.PartialError
+	switch {
+	case errors.As(err, &partial) && assignments != nil:
+		return newSnapshot(courses, assignments, time.Now()), err
+	case errors.Is(err, login.ErrInvalidCredentials), errors.Is(err, context.Canceled):
+		return nil, err
+	case err != nil:
+		snap, loadErr := Load()
+		if loadErr != nil {
+			return nil, fmt.Errorf("%w (offline fallback: %v)", err, loadErr)
+		}
+		snap.FetchErr = err
+		return snap, nil
+	}
+
+	snap := newSnapshot(courses, assignments, time.Now())
+	if err = Save(snap); err != nil {
+		log.Println("failed to save offline snapshot:", err.Error())
+	}
+
+	return snap, nil
+}
+
+func fetch(
+	ctx context.Context,
+	src Source,
+) ([]course.Course, []assignment.Assignment, error) {
+	err := src.Login(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	courses, err := src.Courses(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	assignments, err := src.CoursesAssignments(ctx, courses)
+	return courses, assignments, err
+}
+
+// Filter returns the assignments of the snapshot that opts does not
+// exclude, along with the manual assignments of opts, sorted by deadline.
+func (s *Snapshot) Filter(opts *config.Options) ([]assignment.Assignment, error) {
+	now := time.Now()
+
+	kept := make([]assignment.Assignment, 0, len(s.Assignments))
+	for _, a := range s.Assignments {
+		if !assignment.IsExcluded(opts, a, now) {
+			kept = append(kept, a)
+		}
+	}
+
+	return assignment.MergeManual(kept, s.Courses, opts)
+}
+
+// Path is the snapshot file in the cache directory.
+func Path() (string, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "snapshot.json"), nil
+}
+
+// Save writes the snapshot to the cache directory.
+func Save(snap *Snapshot) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+// Load reads the last snapshot from the cache directory.
+func Load() (*Snapshot, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoSnapshot
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return decode(data)
+}
+
+func decode(data []byte) (*Snapshot, error) {
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	}
+
+	if snap.Version != Version {
+		return nil, fmt.Errorf(
+			"snapshot version %v is not supported, expected %v",
+			snap.Version,
+			Version,
+		)
+	}
+
+	// assignments of the same course share the course, as when fetched
+	courses := make(map[string]*course.Course, len(snap.Courses))
+	for i := range snap.Courses {
+		courses[snap.Courses[i].ID] = &snap.Courses[i]
+	}
+	for i := range snap.Assignments {
+		if snap.Assignments[i].Course == nil {
+			return nil, fmt.Errorf("snapshot assignment %v has no course", snap.Assignments[i].ID)
+		}
+		if crs, ok := courses[snap.Assignments[i].Course.ID]; ok {
+			snap.Assignments[i].Course = crs
+		}
+		// snapshots saved before exercises were fetched have no kinds
+		if snap.Assignments[i].Kind == "" {
+			snap.Assignments[i].Kind = assignment.KindAssignment
+		}
+	}
+
+	snap.Offline = true
+	return &snap, nil
+}
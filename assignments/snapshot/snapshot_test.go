@@ -0,0 +1,143 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+func TestDecode_RoundTrip(t *testing.T) {
+	// Arrange
+	crs := &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"}
+	fetchedAt := time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC)
+	snap := newSnapshot([]course.Course{*crs}, []assignment.Assignment{
+		{ID: "1", Course: crs, Title: "Άσκηση 1", Deadline: fetchedAt.AddDate(0, 0, 1)},
+		{ID: "2", Course: crs, Title: "Άσκηση 2", Deadline: fetchedAt.AddDate(0, 0, 2)},
+	}, fetchedAt)
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Act
+	res, err := decode(data)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !res.Offline || !res.FetchedAt.Equal(fetchedAt) {
+		t.Errorf("Expected an offline snapshot fetched at %v, Actual: %+v", fetchedAt, res)
+	}
+	if len(res.Courses) != 1 || len(res.Assignments) != 2 {
+		t.Fatalf("Expected 1 course and 2 assignments, Actual: %+v", res)
+	}
+	if res.Assignments[0].Course != res.Assignments[1].Course {
+		t.Errorf("Expected assignments of the same course to share it")
+	}
+}
+
+func TestDecode_OtherVersion(t *testing.T) {
+	// Act
+	_, err := decode([]byte(`{"version": 999}`))
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected an error for an unsupported version")
+	}
+}
+
+func TestSnapshotFilter(t *testing.T) {
+	// Arrange
+	ice := &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"}
+	cs := &course.Course{ID: "CS152", Name: "ΑΛΓΟΡΙΘΜΟΙ"}
+	now := time.Now()
+	snap := newSnapshot([]course.Course{*ice, *cs}, []assignment.Assignment{
+		{ID: "1", Course: ice, Title: "Άσκηση 1", Deadline: now.AddDate(0, 0, -1)},
+		{ID: "2", Course: ice, Title: "Άσκηση 2", Deadline: now.AddDate(0, 0, 2)},
+		{ID: "3", Course: cs, Title: "Εργασία", Deadline: now.AddDate(0, 0, 1)},
+	}, now)
+
+	tests := []struct {
+		name     string
+		opts     config.Options
+		expected []string
+	}{
+		{
+			name:     "expired left out",
+			opts:     config.Options{},
+			expected: []string{"3", "2"},
+		},
+		{
+			name:     "expired included",
+			opts:     config.Options{IncludeExpired: true},
+			expected: []string{"1", "3", "2"},
+		},
+		{
+			name: "course excluded",
+			opts: config.Options{
+				ExcludedCourses: map[string]struct{}{"CS152": {}},
+			},
+			expected: []string{"2"},
+		},
+		{
+			name: "manual added",
+			opts: config.Options{
+				ManualAssignments: []config.ManualAssignment{{
+					ID:       "m1",
+					CourseID: "CS152",
+					Title:    "Αναφορά",
+					Deadline: now.AddDate(0, 0, 3).Format(config.ManualDeadlineLayout),
+				}},
+			},
+			expected: []string{"3", "2", "m1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			res, err := snap.Filter(&tt.opts)
+
+			// Assert
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			ids := make([]string, 0, len(res))
+			for _, a := range res {
+				ids = append(ids, a.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected: %v, Actual: %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+func TestUnfiltered(t *testing.T) {
+	// Arrange
+	opts := config.Options{
+		ExcludedCourses:     map[string]struct{}{"CS152": {}},
+		ExcludedAssignments: map[string][]string{"ICE262": {"Άσκηση"}},
+	}
+
+	// Act
+	res := Unfiltered(opts)
+
+	// Assert
+	if !res.IncludeExpired {
+		t.Errorf("Expected: %v, Actual: %v", true, res.IncludeExpired)
+	}
+	if res.ExcludedAssignments != nil {
+		t.Errorf("Expected: %v, Actual: %v", nil, res.ExcludedAssignments)
+	}
+	if _, ok := res.ExcludedCourses["CS152"]; !ok {
+		t.Errorf("Expected: %v, Actual: %v", opts.ExcludedCourses, res.ExcludedCourses)
+	}
+}
@@ -0,0 +1,338 @@
+// Package submission uploads files to assignments through the form of the
+// e-class work module.
+package submission
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/gocolly/colly"
+)
+
+var (
+	// ErrNoForm is returned when the page of an assignment has no
+	// submission form, ex. after its deadline.
+	ErrNoForm = errors.New("the assignment does not accept submissions")
+	// ErrNotSubmitted is returned when the upload went through but the
+	// assignment is still not marked as submitted.
+	ErrNotSubmitted = errors.New("the assignment is not marked as submitted after the upload")
+	// ErrTooManyFiles is returned when the form has fewer file inputs than
+	// the files to submit.
+	ErrTooManyFiles = errors.New("too many files")
+)
+
+// Form is the submission form of an assignment's page.
+type Form struct {
+	// Action is the absolute URL the form is posted to.
+	Action string
+	// Fields are the controls of the form a browser posts, besides the
+	// file inputs, in the order of the page: inputs, checked checkboxes
+	// and radio buttons, selected options, text areas and the first submit
+	// button.
+	Fields []Field
+	// FileFields are the names of the file inputs.
+	FileFields []string
+	// Multiple is set when the only file input accepts several files.
+	Multiple bool
+}
+
+// Field is a name and value pair of a form.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// GetForm reads the submission form of the page of a.
+func GetForm(
+	ctx context.Context,
+	opts *config.Options,
+	a *assignment.Assignment,
+	c *colly.Collector,
+) (*Form, error) {
+	var form *Form
+
+	c.OnHTML("form", func(h *colly.HTMLElement) {
+		if form != nil || h.DOM.Find("input[type=file]").Length() == 0 {
+			return
+		}
+		form = parseForm(h)
+	})
+
+	assignmentURL, err := a.FullURL(opts.BaseDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.Visit(assignmentURL)
+	if err != nil {
+		return nil, err
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if form == nil {
+		return nil, ErrNoForm
+	}
+	return form, nil
+}
+
+func parseForm(h *colly.HTMLElement) *Form {
+	form := &Form{
+		Action: h.Request.AbsoluteURL(h.Attr("action")),
+	}
+	if h.Attr("action") == "" {
+		form.Action = h.Request.URL.String()
+	}
+
+	submitter := false
+	h.ForEach("input[name], select[name], textarea[name], button[name]", func(_ int, el *colly.HTMLElement) {
+		if _, disabled := el.DOM.Attr("disabled"); disabled {
+			return
+		}
+		name := el.Attr("name")
+
+		switch el.Name {
+		case "select":
+			for _, value := range selectedOptions(el) {
+				form.Fields = append(form.Fields, Field{Name: name, Value: value})
+			}
+			return
+		case "textarea":
+			form.Fields = append(form.Fields, Field{Name: name, Value: el.Text})
+			return
+		}
+
+		switch typ := strings.ToLower(el.Attr("type")); {
+		case el.Name == "button" && typ != "" && typ != "submit":
+		case el.Name == "button", typ == "submit", typ == "image":
+			// only the button that submits the form is posted
+			if !submitter {
+				submitter = true
+				form.Fields = append(form.Fields, Field{Name: name, Value: el.Attr("value")})
+			}
+		case typ == "file":
+			form.FileFields = append(form.FileFields, name)
+			_, multiple := el.DOM.Attr("multiple")
+			form.Multiple = multiple || strings.HasSuffix(name, "[]")
+		case typ == "checkbox", typ == "radio":
+			if _, checked := el.DOM.Attr("checked"); !checked {
+				return
+			}
+			value, ok := el.DOM.Attr("value")
+			if !ok {
+				value = "on"
+			}
+			form.Fields = append(form.Fields, Field{Name: name, Value: value})
+		case typ == "button", typ == "reset":
+		default:
+			form.Fields = append(form.Fields, Field{Name: name, Value: el.Attr("value")})
+		}
+	})
+	if len(form.FileFields) > 1 {
+		form.Multiple = false
+	}
+
+	return form
+}
+
+// selectedOptions returns the values of the selected options of a select,
+// or of its first option when a single choice select has none selected.
+func selectedOptions(el *colly.HTMLElement) []string {
+	_, multiple := el.DOM.Attr("multiple")
+
+	var values, enabled []string
+	el.ForEach("option", func(_ int, option *colly.HTMLElement) {
+		if _, disabled := option.DOM.Attr("disabled"); disabled {
+			return
+		}
+		value, ok := option.DOM.Attr("value")
+		if !ok {
+			value = strings.Join(strings.Fields(option.Text), " ")
+		}
+
+		enabled = append(enabled, value)
+		if _, selected := option.DOM.Attr("selected"); selected {
+			values = append(values, value)
+		}
+	})
+
+	switch {
+	case multiple:
+		return values
+	case len(values) > 0:
+		// the last selected option wins, as in a browser
+		return values[len(values)-1:]
+	case len(enabled) > 0:
+		return enabled[:1]
+	}
+	return nil
+}
+
+// Check returns an error when the form cannot take the files at paths.
+func (f *Form) Check(paths []string) error {
+	if len(paths) == 0 {
+		return errors.New("no files to submit")
+	}
+	if !f.Multiple && len(paths) > len(f.FileFields) {
+		return fmt.Errorf(
+			"%w, the assignment accepts at most %v file(s)",
+			ErrTooManyFiles,
+			len(f.FileFields),
+		)
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return fmt.Errorf("%v is a directory", path)
+		}
+	}
+
+	return nil
+}
+
+// Submit uploads the files at paths through the form of a and then reads
+// the assignments of its course again, to confirm that it is marked as
+// submitted.
+func Submit(
+	ctx context.Context,
+	opts *config.Options,
+	a *assignment.Assignment,
+	form *Form,
+	paths []string,
+	c *colly.Collector,
+) error {
+	err := form.Check(paths)
+	if err != nil {
+		return err
+	}
+
+	err = upload(form, paths, c.Clone())
+	if err != nil {
+		return err
+	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	return confirm(ctx, opts, a, c.Clone())
+}
+
+func upload(form *Form, paths []string, c *colly.Collector) error {
+	body, contentType, err := encode(form, paths)
+	if err != nil {
+		return err
+	}
+
+	var alert string
+	c.OnHTML(".alert-danger", func(h *colly.HTMLElement) {
+		if alert == "" {
+			alert = strings.TrimSpace(h.Text)
+		}
+	})
+
+	hdr := http.Header{}
+	hdr.Set("Content-Type", contentType)
+	hdr.Set("User-Agent", c.UserAgent)
+
+	err = c.Request(http.MethodPost, form.Action, body, nil, hdr)
+	if err != nil {
+		return err
+	}
+
+	if alert != "" {
+		return fmt.Errorf("e-class rejected the submission: %v", alert)
+	}
+	return nil
+}
+
+// encode builds the multipart body of the form with the files at paths,
+// the way a browser posts it.
+func encode(form *Form, paths []string) (io.Reader, string, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	for _, field := range form.Fields {
+		if err := w.WriteField(field.Name, field.Value); err != nil {
+			return nil, "", err
+		}
+	}
+
+	for i, path := range paths {
+		field := form.FileFields[0]
+		if !form.Multiple {
+			field = form.FileFields[i]
+		}
+
+		if err := writeFile(w, field, path); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return &body, w.FormDataContentType(), nil
+}
+
+func writeFile(w *multipart.Writer, field, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	part, err := w.CreateFormFile(field, filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, file)
+	return err
+}
+
+// confirm reads the assignments of the course of a and checks that a is
+// marked as submitted.
+func confirm(
+	ctx context.Context,
+	opts *config.Options,
+	a *assignment.Assignment,
+	c *colly.Collector,
+) error {
+	c.AllowURLRevisit = true
+
+	// the assignment was picked by hand, so it is looked for even when the
+	// options exclude it
+	confirmOpts := *opts
+	confirmOpts.IncludeExpired = true
+	confirmOpts.FetchDetails = false
+	confirmOpts.ExcludedCourses = nil
+	confirmOpts.ExcludedAssignments = nil
+
+	assignments, err := assignment.FetchCourse(ctx, &confirmOpts, *a.Course, c)
+	if err != nil {
+		return err
+	}
+
+	for _, fetched := range assignments {
+		if fetched.ID == a.ID && !fetched.IsExercise() && fetched.IsSent {
+			a.IsSent = true
+			return nil
+		}
+	}
+	return ErrNotSubmitted
+}
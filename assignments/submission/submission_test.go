@@ -0,0 +1,200 @@
+package submission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+	"github.com/Huray-hub/eclass-utils/assignments/internal/testserver"
+	"github.com/gocolly/colly"
+)
+
+const assignmentPage = `<html><body>
+<form enctype="multipart/form-data" action="index.php?course=ICE262" method="post">
+	<input type="hidden" name="id" value="24692">
+	<input type="hidden" name="token" value="secret">
+	<input type="file" name="userfile">
+	<textarea name="stud_comments"></textarea>
+	<input class="btn btn-primary" type="submit" value="Αποστολή" name="work_submit">
+</form>
+</body></html>`
+
+const workPage = `<html><body>
+<table id="assignment_table"><tbody>
+<tr>
+	<td><a href="index.php?course=ICE262&id=24692">Άσκηση 1</a></td>
+	<td>Τετάρτη 21 Δεκεμβρίου 2050 - 11:59 μ.μ.(απομένουν 19 ημέρες 3 ώρες 8 λεπτά)</td>
+	<td><i class="fa %v"></i></td>
+</tr>
+</tbody></table>
+</body></html>`
+
+// fakeEclass serves an assignment with a submission form. When accept is
+// unset, uploads are received but the assignment stays unsubmitted.
+type fakeEclass struct {
+	accept    bool
+	submitted bool
+	uploaded  string
+}
+
+func (f *fakeEclass) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/modules/work":
+		icon := "fa-square-o"
+		if f.submitted {
+			icon = "fa-check-square-o"
+		}
+		fmt.Fprintf(w, workPage, icon)
+	case r.Method == http.MethodGet:
+		fmt.Fprint(w, assignmentPage)
+	case r.Method == http.MethodPost:
+		if r.FormValue("token") != "secret" || r.FormValue("id") != "24692" {
+			fmt.Fprint(w, `<div class="alert alert-danger">Μη έγκυρο αίτημα</div>`)
+			return
+		}
+		file, header, err := r.FormFile("userfile")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		f.uploaded = header.Filename + ":" + string(data)
+		f.submitted = f.accept
+		fmt.Fprint(w, `<div class="alert alert-success">Η εργασία σας στάλθηκε</div>`)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	dir := t.TempDir()
+	solution := filepath.Join(dir, "lysi.zip")
+	if err := os.WriteFile(solution, []byte("zip"), 0644); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tests := []struct {
+		name             string
+		accept           bool
+		excluded         bool
+		paths            []string
+		expectedErr      error
+		expectedUploaded string
+	}{
+		{"accepted", true, false, []string{solution}, nil, "lysi.zip:zip"},
+		{"excluded by the options", true, true, []string{solution}, nil, "lysi.zip:zip"},
+		{"not marked as submitted", false, false, []string{solution}, ErrNotSubmitted, "lysi.zip:zip"},
+		{"too many files", true, false, []string{solution, solution}, ErrTooManyFiles, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			eclass := &fakeEclass{accept: tt.accept}
+			server := httptest.NewTLSServer(eclass)
+			defer server.Close()
+
+			c := colly.NewCollector()
+			c.WithTransport(testserver.Transport(server))
+
+			opts := &config.Options{BaseDomain: "example.com"}
+			if tt.excluded {
+				opts.ExcludedAssignments = map[string][]string{"ICE262": {"Άσκηση"}}
+			}
+			a := &assignment.Assignment{
+				ID:       "24692",
+				Course:   &course.Course{ID: "ICE262"},
+				Deadline: time.Now().Add(time.Hour),
+			}
+
+			// Act
+			form, err := GetForm(context.Background(), opts, a, c.Clone())
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			err = Submit(context.Background(), opts, a, form, tt.paths, c)
+
+			// Assert
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Expected: %v, Actual: %v", tt.expectedErr, err)
+			}
+			if eclass.uploaded != tt.expectedUploaded {
+				t.Errorf("Expected: %v, Actual: %v", tt.expectedUploaded, eclass.uploaded)
+			}
+			if a.IsSent != (tt.expectedErr == nil) {
+				t.Errorf("Expected: %v, Actual: %v", tt.expectedErr == nil, a.IsSent)
+			}
+		})
+	}
+}
+
+const groupAssignmentPage = `<html><body>
+<form action="index.php?course=ICE262" method="post">
+	<input type="hidden" name="id" value="31337">
+	<select name="group_id">
+		<option value="">-</option>
+		<option value="12" selected>Ομάδα 3</option>
+	</select>
+	<select name="ta" multiple>
+		<option selected>Α. Παπαδόπουλος</option>
+		<option value="2" selected disabled>Β. Γεωργίου</option>
+	</select>
+	<textarea name="stud_comments">Η λύση μας</textarea>
+	<input type="text" name="title" value="Ομάδα 3">
+	<input type="text" name="note" value="x" disabled>
+	<input type="checkbox" name="agree" checked>
+	<input type="checkbox" name="late" value="1">
+	<input type="radio" name="lang" value="el">
+	<input type="radio" name="lang" value="en" checked>
+	<input type="file" name="userfile">
+	<button type="button" name="preview">Προεπισκόπηση</button>
+	<button name="work_submit" value="1">Αποστολή</button>
+	<input type="submit" name="cancel" value="Ακύρωση">
+</form>
+</body></html>`
+
+func TestGetForm(t *testing.T) {
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, groupAssignmentPage)
+	}))
+	defer server.Close()
+
+	c := colly.NewCollector()
+	c.WithTransport(testserver.Transport(server))
+
+	opts := &config.Options{BaseDomain: "example.com"}
+	a := &assignment.Assignment{ID: "31337", Course: &course.Course{ID: "ICE262"}}
+
+	// Act
+	form, err := GetForm(context.Background(), opts, a, c)
+
+	// Assert
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []Field{
+		{"id", "31337"},
+		{"group_id", "12"},
+		{"ta", "Α. Παπαδόπουλος"},
+		{"stud_comments", "Η λύση μας"},
+		{"title", "Ομάδα 3"},
+		{"agree", "on"},
+		{"lang", "en"},
+		{"work_submit", "1"},
+	}
+	if fmt.Sprint(form.Fields) != fmt.Sprint(expected) {
+		t.Errorf("Expected: %v, Actual: %v", expected, form.Fields)
+	}
+	if fmt.Sprint(form.FileFields) != "[userfile]" {
+		t.Errorf("Expected: %v, Actual: %v", "[userfile]", form.FileFields)
+	}
+}
@@ -0,0 +1,28 @@
+// Package text compares the Greek text of e-class pages.
+package text
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var stripAccents = transform.Chain(
+	norm.NFD,
+	runes.Remove(runes.In(unicode.Mn)),
+	norm.NFC,
+)
+
+// Normalize lower-cases s and strips its accents and diaeresis, so that
+// "Άσκηση" and "ασκηση" compare equal. The final sigma is folded to σ.
+func Normalize(s string) string {
+	res, _, err := transform.String(stripAccents, s)
+	if err != nil {
+		res = s
+	}
+
+	return strings.ReplaceAll(strings.ToLower(res), "ς", "σ")
+}
@@ -0,0 +1,26 @@
+package text
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected string
+	}{
+		{s: "Άσκηση", expected: "ασκηση"},
+		{s: "ΑΣΚΗΣΗ", expected: "ασκηση"},
+		{s: "Ώρες γραφείου", expected: "ωρεσ γραφειου"},
+		{s: "Προϋποθέσεις", expected: "προυποθεσεισ"},
+		{s: "CS152", expected: "cs152"},
+	}
+
+	for _, tt := range tests {
+		// Act
+		actual := Normalize(tt.s)
+
+		// Assert
+		if actual != tt.expected {
+			t.Errorf("Expected: %v, Actual: %v", tt.expected, actual)
+		}
+	}
+}
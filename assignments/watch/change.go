@@ -0,0 +1,139 @@
+// Package watch polls the assignments on an interval and notifies about the
+// changes between polls.
+package watch
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+)
+
+// ChangeType is what changed in an assignment between two polls.
+type ChangeType string
+
+const (
+	// ChangeNew is an assignment posted since the last poll.
+	ChangeNew ChangeType = "new"
+	// ChangeDeadline is an assignment whose deadline moved.
+	ChangeDeadline ChangeType = "deadline"
+	// ChangeTitle is an assignment that was renamed.
+	ChangeTitle ChangeType = "title"
+	// ChangeRemoved is an assignment taken down before its deadline.
+	ChangeRemoved ChangeType = "removed"
+	// ChangeSubmission is an assignment whose submission status changed.
+	ChangeSubmission ChangeType = "submission"
+	// ChangeReminder is a reminder of the deadline of an assignment that
+	// is not submitted yet, see reminder.Engine.
+	ChangeReminder ChangeType = "reminder"
+)
+
+// Change is a change of a single assignment. An assignment with several
+// changes has one of each type.
+type Change struct {
+	Type ChangeType `json:"type"`
+	// Assignment is the assignment as of the latest poll, or as of the
+	// previous one when removed.
+	Assignment assignment.Assignment `json:"assignment"`
+	// Previous is the assignment as of the previous poll, unset for new
+	// ones and reminders.
+	Previous *assignment.Assignment `json:"previous,omitempty"`
+	// LeadTime is the time before the deadline a reminder is set for.
+	LeadTime time.Duration `json:"leadTime,omitempty"`
+}
+
+func (c Change) String() string {
+	a := c.Assignment
+	switch c.Type {
+	case ChangeNew:
+		return fmt.Sprintf(
+			"Νέα εργασία στο %v: %v, προθεσμία %v",
+			a.Course.Name, a.Title, a.Deadline.Format("02/01/2006 15:04"),
+		)
+	case ChangeDeadline:
+		return fmt.Sprintf(
+			"Αλλαγή προθεσμίας στο %v: %v, από %v σε %v",
+			a.Course.Name,
+			a.Title,
+			c.Previous.Deadline.Format("02/01/2006 15:04"),
+			a.Deadline.Format("02/01/2006 15:04"),
+		)
+	case ChangeTitle:
+		return fmt.Sprintf(
+			"Μετονομασία εργασίας στο %v: %v σε %v",
+			a.Course.Name, c.Previous.Title, a.Title,
+		)
+	case ChangeRemoved:
+		return fmt.Sprintf("Αφαίρεση εργασίας στο %v: %v", a.Course.Name, a.Title)
+	case ChangeSubmission:
+		if a.IsSent {
+			return fmt.Sprintf("Υποβλήθηκε η εργασία στο %v: %v", a.Course.Name, a.Title)
+		}
+		return fmt.Sprintf("Ακυρώθηκε η υποβολή της εργασίας στο %v: %v", a.Course.Name, a.Title)
+	case ChangeReminder:
+		return fmt.Sprintf(
+			"Υπενθύμιση για το %v: %v, προθεσμία %v",
+			a.Course.Name, a.Title, a.Deadline.Format("02/01/2006 15:04"),
+		)
+	}
+	return fmt.Sprintf("%v: %v", c.Type, a.Title)
+}
+
+// Diff compares two polls of the assignments by their Key. Assignments
+// missing from next after their deadline are expired rather than removed,
+// so they are not reported. Likewise, assignments missing from prev after
+// their deadline are not new but expired ones that came back, ex. once
+// graded. Manual assignments are left out. The changes are in the order of
+// next, followed by the removed ones.
+func Diff(prev, next []assignment.Assignment, now time.Time) []Change {
+	prevByKey := make(map[string]assignment.Assignment, len(prev))
+	for _, a := range prev {
+		if !a.Manual {
+			prevByKey[a.Key()] = a
+		}
+	}
+
+	changes := make([]Change, 0)
+	seen := make(map[string]struct{}, len(next))
+
+	for _, a := range next {
+		if a.Manual {
+			continue
+		}
+		seen[a.Key()] = struct{}{}
+
+		p, ok := prevByKey[a.Key()]
+		if !ok {
+			if !a.Deadline.Before(now) {
+				changes = append(changes, Change{Type: ChangeNew, Assignment: a})
+			}
+			continue
+		}
+
+		previous := p
+		if !a.Deadline.Equal(p.Deadline) {
+			changes = append(changes, Change{Type: ChangeDeadline, Assignment: a, Previous: &previous})
+		}
+		if a.Title != p.Title {
+			changes = append(changes, Change{Type: ChangeTitle, Assignment: a, Previous: &previous})
+		}
+		if a.IsSent != p.IsSent {
+			changes = append(changes, Change{Type: ChangeSubmission, Assignment: a, Previous: &previous})
+		}
+	}
+
+	removed := make([]Change, 0)
+	for key, p := range prevByKey {
+		if _, ok := seen[key]; ok || p.Deadline.Before(now) {
+			continue
+		}
+		previous := p
+		removed = append(removed, Change{Type: ChangeRemoved, Assignment: p, Previous: &previous})
+	}
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].Assignment.Key() < removed[j].Assignment.Key()
+	})
+
+	return append(changes, removed...)
+}
@@ -0,0 +1,44 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+)
+
+// Notifier delivers the changes found by a poll, ex. by mail or to a chat.
+type Notifier interface {
+	Notify(ctx context.Context, changes []Change) error
+}
+
+// Observer is a Notifier that also sees the assignments of every poll, ex.
+// to send a digest of the upcoming deadlines.
+type Observer interface {
+	Notifier
+	Observe(ctx context.Context, assignments []assignment.Assignment) error
+}
+
+// NotifierFunc is a function used as a Notifier.
+type NotifierFunc func(ctx context.Context, changes []Change) error
+
+func (f NotifierFunc) Notify(ctx context.Context, changes []Change) error {
+	return f(ctx, changes)
+}
+
+// WriterNotifier writes every change as a line to W, ex. os.Stdout.
+type WriterNotifier struct {
+	W io.Writer
+}
+
+func (n WriterNotifier) Notify(_ context.Context, changes []Change) error {
+	now := time.Now().Format("02/01/2006 15:04")
+	for _, c := range changes {
+		if _, err := fmt.Fprintf(n.W, "%v %v\n", now, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
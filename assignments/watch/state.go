@@ -0,0 +1,60 @@
+package watch
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/config"
+)
+
+// State is the last poll of the assignments, kept across restarts so that
+// the changes in between are not missed nor reported twice.
+type State struct {
+	// CheckedAt is zero until the first poll.
+	CheckedAt   time.Time               `json:"checkedAt"`
+	Assignments []assignment.Assignment `json:"assignments"`
+	// Undelivered are the changes that a notifier failed to deliver, by
+	// the notifier's key, to be sent again on the next poll.
+	Undelivered map[string][]Change `json:"undelivered,omitempty"`
+}
+
+// StatePath is the file in the cache directory that keeps the State.
+func StatePath() (string, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "watch.json"), nil
+}
+
+// LoadState reads the state at path. A missing file is an empty state,
+// whose first poll reports no changes.
+func LoadState(path string) (*State, error) {
+	state := &State{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Save writes the state to path.
+func (s *State) Save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
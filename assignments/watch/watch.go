@@ -0,0 +1,215 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/login"
+	"github.com/Huray-hub/eclass-utils/assignments/reminder"
+)
+
+// DefaultInterval is the time between polls when none is configured.
+const DefaultInterval = 30 * time.Minute
+
+// Watcher polls the assignments and passes the changes since the previous
+// poll to its notifiers.
+type Watcher struct {
+	// Fetch polls the assignments, ex. through assignment.GetContext.
+	Fetch func(ctx context.Context) ([]assignment.Assignment, error)
+	// Interval is the time between polls, DefaultInterval if not set, to
+	// which a random delay of up to Jitter is added so that polls do not hit
+	// e-class at fixed times.
+	Interval time.Duration
+	Jitter   time.Duration
+	// StatePath is the file that keeps the last poll, see StatePath.
+	StatePath string
+	Notifiers []Notifier
+	// Reminders, if set, adds the due reminders to the changes of every
+	// poll.
+	Reminders *reminder.Engine
+	// Now returns the current time, time.Now if not set.
+	Now func() time.Time
+
+	state *State
+	rand  *rand.Rand
+}
+
+// Run polls until ctx is cancelled, the first time right away. Failed polls
+// are logged and retried on the next interval, except for rejected
+// credentials, which stop the watcher.
+func (w *Watcher) Run(ctx context.Context) error {
+	for {
+		_, err := w.Poll(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case errors.Is(err, login.ErrInvalidCredentials):
+			return err
+		case err != nil:
+			log.Println("watch:", err.Error())
+		}
+
+		timer := time.NewTimer(w.delay())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// Poll fetches the assignments once, notifies about the changes since the
+// previous poll and the due reminders, passes the assignments to the
+// notifiers that are Observers and keeps them for the next poll. The first
+// poll without a state only reports reminders. The assignments of the
+// courses that fail to load are kept from the previous poll instead of
+// being reported as removed. Changes a notifier fails to deliver are sent
+// to it again on the next poll.
+func (w *Watcher) Poll(ctx context.Context) ([]Change, error) {
+	if w.state == nil {
+		state, err := LoadState(w.StatePath)
+		if err != nil {
+			return nil, err
+		}
+		w.state = state
+	}
+
+	assignments, err := w.Fetch(ctx)
+
+	var partial *assignment.PartialError
+	switch {
+	case errors.As(err, &partial) && assignments != nil:
+		log.Println("watch:", err.Error())
+		assignments = append(assignments, failedCourses(w.state.Assignments, partial)...)
+	case err != nil:
+		return nil, err
+	}
+
+	now := w.now()
+	var changes []Change
+	if !w.state.CheckedAt.IsZero() {
+		changes = Diff(w.state.Assignments, assignments, now)
+	}
+	changes = append(changes, w.dueReminders(assignments)...)
+
+	var failures []string
+	undelivered := make(map[string][]Change)
+	for i, n := range w.Notifiers {
+		key := notifierKey(i, n)
+		pending := append(w.pending(key, now), changes...)
+		if len(pending) > 0 {
+			if err = n.Notify(ctx, pending); err != nil {
+				failures = append(failures, err.Error())
+				undelivered[key] = pending
+			}
+		}
+		if o, ok := n.(Observer); ok {
+			if err = o.Observe(ctx, assignments); err != nil {
+				failures = append(failures, err.Error())
+			}
+		}
+	}
+
+	w.state.CheckedAt = now
+	w.state.Assignments = assignments
+	w.state.Undelivered = undelivered
+	if err = w.state.Save(w.StatePath); err != nil {
+		return changes, err
+	}
+
+	if len(failures) > 0 {
+		return changes, fmt.Errorf(
+			"failed to notify %v time(s):\n%v",
+			len(failures),
+			strings.Join(failures, "\n"),
+		)
+	}
+	return changes, nil
+}
+
+// pending returns the changes that the notifier with the given key failed
+// to deliver on the previous poll, except for those of assignments whose
+// deadline has passed since.
+func (w *Watcher) pending(key string, now time.Time) []Change {
+	pending := make([]Change, 0, len(w.state.Undelivered[key]))
+	for _, c := range w.state.Undelivered[key] {
+		if c.Assignment.Deadline.After(now) {
+			pending = append(pending, c)
+		}
+	}
+	return pending
+}
+
+// notifierKey tells the notifiers apart in the state by their position and
+// type, as they are created in the same order from the config file.
+func notifierKey(i int, n Notifier) string {
+	return fmt.Sprintf("%v/%T", i, n)
+}
+
+// dueReminders returns the due reminders as changes. Failures are logged,
+// as the changes of the poll are still worth notifying about.
+func (w *Watcher) dueReminders(assignments []assignment.Assignment) []Change {
+	if w.Reminders == nil {
+		return nil
+	}
+
+	due, err := w.Reminders.Due(assignments)
+	if err != nil {
+		log.Println("watch: reminders:", err.Error())
+	}
+
+	changes := make([]Change, 0, len(due))
+	for _, r := range due {
+		changes = append(changes, Change{
+			Type:       ChangeReminder,
+			Assignment: r.Assignment,
+			LeadTime:   r.LeadTime,
+		})
+	}
+	return changes
+}
+
+// failedCourses returns the assignments of prev in the courses that failed.
+func failedCourses(prev []assignment.Assignment, partial *assignment.PartialError) []assignment.Assignment {
+	failed := make(map[string]struct{}, len(partial.Errors))
+	for _, err := range partial.Errors {
+		failed[err.Course.ID] = struct{}{}
+	}
+
+	kept := make([]assignment.Assignment, 0)
+	for _, a := range prev {
+		if _, ok := failed[a.Course.ID]; ok {
+			kept = append(kept, a)
+		}
+	}
+	return kept
+}
+
+func (w *Watcher) now() time.Time {
+	if w.Now != nil {
+		return w.Now()
+	}
+	return time.Now()
+}
+
+func (w *Watcher) delay() time.Duration {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	if w.Jitter <= 0 {
+		return interval
+	}
+	if w.rand == nil {
+		w.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return interval + time.Duration(w.rand.Int63n(int64(w.Jitter)))
+}
@@ -0,0 +1,257 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Huray-hub/eclass-utils/assignments/assignment"
+	"github.com/Huray-hub/eclass-utils/assignments/course"
+)
+
+var (
+	now      = time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC)
+	ice262   = &course.Course{ID: "ICE262", Name: "ΑΝΑΚΤΗΣΗ ΠΛΗΡΟΦΟΡΙΑΣ"}
+	cs152    = &course.Course{ID: "CS152", Name: "Αλγόριθμοι"}
+	deadline = now.AddDate(0, 0, 7)
+)
+
+func newAssignment(crs *course.Course, id string, title string) assignment.Assignment {
+	return assignment.Assignment{
+		ID:       id,
+		Kind:     assignment.KindAssignment,
+		Course:   crs,
+		Title:    title,
+		Deadline: deadline,
+	}
+}
+
+func TestDiff(t *testing.T) {
+	moved := newAssignment(ice262, "1", "Άσκηση 1")
+	moved.Deadline = deadline.AddDate(0, 0, 2)
+
+	sent := newAssignment(ice262, "1", "Άσκηση 1")
+	sent.IsSent = true
+
+	expired := newAssignment(ice262, "2", "Άσκηση 2")
+	expired.Deadline = now.Add(-time.Hour)
+
+	exercise := newAssignment(ice262, "1", "Quiz 1")
+	exercise.Kind = assignment.KindExercise
+
+	manual := newAssignment(ice262, "m1", "Παρουσίαση")
+	manual.Manual = true
+
+	tests := []struct {
+		name     string
+		prev     []assignment.Assignment
+		next     []assignment.Assignment
+		expected []ChangeType
+	}{
+		{
+			name:     "unchanged",
+			prev:     []assignment.Assignment{newAssignment(ice262, "1", "Άσκηση 1")},
+			next:     []assignment.Assignment{newAssignment(ice262, "1", "Άσκηση 1")},
+			expected: []ChangeType{},
+		},
+		{
+			name:     "new in another course with the same ID",
+			prev:     []assignment.Assignment{newAssignment(ice262, "1", "Άσκηση 1")},
+			next:     []assignment.Assignment{newAssignment(ice262, "1", "Άσκηση 1"), newAssignment(cs152, "1", "Εργασία")},
+			expected: []ChangeType{ChangeNew},
+		},
+		{
+			name:     "new exercise with the ID of an assignment",
+			prev:     []assignment.Assignment{newAssignment(ice262, "1", "Άσκηση 1")},
+			next:     []assignment.Assignment{newAssignment(ice262, "1", "Άσκηση 1"), exercise},
+			expected: []ChangeType{ChangeNew},
+		},
+		{
+			name:     "deadline moved",
+			prev:     []assignment.Assignment{newAssignment(ice262, "1", "Άσκηση 1")},
+			next:     []assignment.Assignment{moved},
+			expected: []ChangeType{ChangeDeadline},
+		},
+		{
+			name:     "renamed and submitted",
+			prev:     []assignment.Assignment{newAssignment(ice262, "1", "Άσκηση")},
+			next:     []assignment.Assignment{sent},
+			expected: []ChangeType{ChangeTitle, ChangeSubmission},
+		},
+		{
+			name:     "removed",
+			prev:     []assignment.Assignment{newAssignment(ice262, "1", "Άσκηση 1")},
+			next:     []assignment.Assignment{},
+			expected: []ChangeType{ChangeRemoved},
+		},
+		{
+			name:     "expired",
+			prev:     []assignment.Assignment{expired},
+			next:     []assignment.Assignment{},
+			expected: []ChangeType{},
+		},
+		{
+			name:     "expired back after grading",
+			prev:     []assignment.Assignment{},
+			next:     []assignment.Assignment{expired},
+			expected: []ChangeType{},
+		},
+		{
+			name:     "manual",
+			prev:     []assignment.Assignment{},
+			next:     []assignment.Assignment{manual},
+			expected: []ChangeType{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			changes := Diff(tt.prev, tt.next, now)
+
+			// Assert
+			actual := make([]ChangeType, 0, len(changes))
+			for _, c := range changes {
+				actual = append(actual, c.Type)
+			}
+			if len(actual) != len(tt.expected) {
+				t.Fatalf("Expected: %v, Actual: %v", tt.expected, actual)
+			}
+			for i := range actual {
+				if actual[i] != tt.expected[i] {
+					t.Errorf("Expected: %v, Actual: %v", tt.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestWatcherPoll(t *testing.T) {
+	// Arrange
+	statePath := filepath.Join(t.TempDir(), "watch.json")
+
+	polls := [][]assignment.Assignment{
+		{newAssignment(ice262, "1", "Άσκηση 1"), newAssignment(cs152, "5", "Εργασία")},
+		{newAssignment(ice262, "1", "Άσκηση 1"), newAssignment(ice262, "2", "Άσκηση 2")},
+	}
+	poll := 0
+	fetch := func(context.Context) ([]assignment.Assignment, error) {
+		defer func() { poll++ }()
+		if poll == 1 {
+			// CS152 fails to load, so its assignment is not removed
+			return polls[poll], &assignment.PartialError{
+				Errors: []*assignment.CourseError{{Course: *cs152, Err: errors.New("timeout")}},
+			}
+		}
+		return polls[poll], nil
+	}
+
+	var notified []Change
+	newWatcher := func() *Watcher {
+		return &Watcher{
+			Fetch:     fetch,
+			StatePath: statePath,
+			Notifiers: []Notifier{NotifierFunc(func(_ context.Context, changes []Change) error {
+				notified = append(notified, changes...)
+				return nil
+			})},
+			Now: func() time.Time { return now },
+		}
+	}
+
+	// Act
+	first, err := newWatcher().Poll(context.Background())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// a restarted watcher picks up the state of the previous one
+	second, err := newWatcher().Poll(context.Background())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Assert
+	if len(first) != 0 {
+		t.Errorf("Expected: %v, Actual: %v", "no changes on the first poll", first)
+	}
+	if len(second) != 1 || second[0].Type != ChangeNew || second[0].Assignment.Key() != "ICE262/2" {
+		t.Errorf("Expected: %v, Actual: %v", "new ICE262/2", second)
+	}
+	if len(notified) != 1 {
+		t.Errorf("Expected: %v, Actual: %v", 1, len(notified))
+	}
+
+	state, err := LoadState(statePath)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(state.Assignments) != 3 || !state.CheckedAt.Equal(now) {
+		t.Errorf("Expected: %v, Actual: %v", "3 assignments checked at now", state)
+	}
+}
+
+func TestWatcherPoll_Undelivered(t *testing.T) {
+	// Arrange
+	statePath := filepath.Join(t.TempDir(), "watch.json")
+
+	polls := [][]assignment.Assignment{
+		{newAssignment(ice262, "1", "Άσκηση 1")},
+		{newAssignment(ice262, "1", "Άσκηση 1"), newAssignment(ice262, "2", "Άσκηση 2")},
+		{newAssignment(ice262, "1", "Άσκηση 1"), newAssignment(ice262, "2", "Άσκηση 2"), newAssignment(cs152, "5", "Εργασία")},
+	}
+	poll := 0
+	fetch := func(context.Context) ([]assignment.Assignment, error) {
+		defer func() { poll++ }()
+		return polls[poll], nil
+	}
+
+	// the first notifier is down during the second poll
+	var failing, working [][]string
+	notifier := func(notified *[][]string, down func() bool) Notifier {
+		return NotifierFunc(func(_ context.Context, changes []Change) error {
+			if down() {
+				return errors.New("unreachable")
+			}
+			keys := make([]string, 0, len(changes))
+			for _, c := range changes {
+				keys = append(keys, c.Assignment.Key())
+			}
+			*notified = append(*notified, keys)
+			return nil
+		})
+	}
+	newWatcher := func() *Watcher {
+		return &Watcher{
+			Fetch:     fetch,
+			StatePath: statePath,
+			Notifiers: []Notifier{
+				notifier(&failing, func() bool { return poll == 2 }),
+				notifier(&working, func() bool { return false }),
+			},
+			Now: func() time.Time { return now },
+		}
+	}
+
+	// Act
+	var errs []error
+	for range polls {
+		_, err := newWatcher().Poll(context.Background())
+		errs = append(errs, err)
+	}
+
+	// Assert
+	if errs[0] != nil || errs[1] == nil || errs[2] != nil {
+		t.Errorf("Expected: %v, Actual: %v", "only the second poll to fail", errs)
+	}
+	expectedFailing := "[[ICE262/2 CS152/5]]"
+	if fmt.Sprint(failing) != expectedFailing {
+		t.Errorf("Expected: %v, Actual: %v", expectedFailing, failing)
+	}
+	expectedWorking := "[[ICE262/2] [CS152/5]]"
+	if fmt.Sprint(working) != expectedWorking {
+		t.Errorf("Expected: %v, Actual: %v", expectedWorking, working)
+	}
+}